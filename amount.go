@@ -0,0 +1,224 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// RoundingMode defines what happens with digits beyond divisibility while parsing
+type RoundingMode int
+
+const (
+	// RoundExact returns ErrAmountPrecision when non zero digits would be lost
+	RoundExact RoundingMode = iota
+	// RoundDown truncates extra digits
+	RoundDown
+	// RoundUp rounds away from zero when any of extra digits is non zero
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, half is rounded away from zero
+	RoundHalfUp
+)
+
+var (
+	zeroAmountValue = new(big.Int)
+	maxAmountValue  = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+// Amount is fixed-point mosaic quantity. Value is stored in the smallest units
+// and must fit into uint64 range. Zero value is 0 with divisibility 0
+type Amount struct {
+	value        *big.Int
+	divisibility uint8
+}
+
+func NewAmount(value *big.Int, divisibility uint8) (*Amount, error) {
+	if value == nil || value.Sign() < 0 || value.Cmp(maxAmountValue) > 0 {
+		return nil, ErrAmountOverflow
+	}
+
+	return &Amount{
+		value:        new(big.Int).Set(value),
+		divisibility: divisibility,
+	}, nil
+}
+
+func NewAmountFromUint64(value uint64, divisibility uint8) *Amount {
+	return &Amount{
+		value:        new(big.Int).SetUint64(value),
+		divisibility: divisibility,
+	}
+}
+
+// ParseAmount parses decimal string like "1234.567890"
+func ParseAmount(s string, divisibility uint8, mode RoundingMode) (*Amount, error) {
+	intPart, fracPart := s, ""
+
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+
+	if len(intPart) == 0 && len(fracPart) == 0 || !isDecimalDigits(intPart) || !isDecimalDigits(fracPart) {
+		return nil, ErrAmountInvalidFormat
+	}
+
+	roundUp := false
+
+	if len(fracPart) > int(divisibility) {
+		extra := fracPart[divisibility:]
+		fracPart = fracPart[:divisibility]
+		lost := strings.Trim(extra, "0") != ""
+
+		switch mode {
+		case RoundExact:
+			if lost {
+				return nil, ErrAmountPrecision
+			}
+		case RoundUp:
+			roundUp = lost
+		case RoundHalfUp:
+			roundUp = extra[0] >= '5'
+		}
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", int(divisibility)-len(fracPart))
+
+	value, ok := new(big.Int).SetString("0"+digits, 10)
+	if !ok {
+		return nil, ErrAmountInvalidFormat
+	}
+
+	if roundUp {
+		value.Add(value, big.NewInt(1))
+	}
+
+	return NewAmount(value, divisibility)
+}
+
+func isDecimalDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// amountValue returns value of amount, nil value of zero Amount is 0. Result must not be modified
+func (ref *Amount) amountValue() *big.Int {
+	if ref.value == nil {
+		return zeroAmountValue
+	}
+
+	return ref.value
+}
+
+// Value returns copy of value in the smallest units
+func (ref *Amount) Value() *big.Int {
+	return new(big.Int).Set(ref.amountValue())
+}
+
+func (ref *Amount) Divisibility() uint8 {
+	return ref.divisibility
+}
+
+// Uint64 returns value in the smallest units
+func (ref *Amount) Uint64() uint64 {
+	return ref.amountValue().Uint64()
+}
+
+// String returns decimal representation with exactly divisibility fractional digits
+func (ref *Amount) String() string {
+	digits := ref.amountValue().String()
+	div := int(ref.divisibility)
+
+	if div == 0 {
+		return digits
+	}
+
+	if len(digits) <= div {
+		digits = strings.Repeat("0", div-len(digits)+1) + digits
+	}
+
+	return digits[:len(digits)-div] + "." + digits[len(digits)-div:]
+}
+
+func (ref *Amount) Add(other *Amount) (*Amount, error) {
+	if ref.divisibility != other.divisibility {
+		return nil, ErrAmountDivisibilityMismatch
+	}
+
+	return NewAmount(new(big.Int).Add(ref.amountValue(), other.amountValue()), ref.divisibility)
+}
+
+func (ref *Amount) Sub(other *Amount) (*Amount, error) {
+	if ref.divisibility != other.divisibility {
+		return nil, ErrAmountDivisibilityMismatch
+	}
+
+	return NewAmount(new(big.Int).Sub(ref.amountValue(), other.amountValue()), ref.divisibility)
+}
+
+// Mul multiplies amount by integer factor
+func (ref *Amount) Mul(factor uint64) (*Amount, error) {
+	return NewAmount(new(big.Int).Mul(ref.amountValue(), new(big.Int).SetUint64(factor)), ref.divisibility)
+}
+
+// Cmp compares amounts by their decimal values, so divisibilities may differ
+func (ref *Amount) Cmp(other *Amount) int {
+	a, b := ref.amountValue(), other.amountValue()
+
+	switch {
+	case ref.divisibility < other.divisibility:
+		a = scaleAmountValue(a, other.divisibility-ref.divisibility)
+	case ref.divisibility > other.divisibility:
+		b = scaleAmountValue(b, ref.divisibility-other.divisibility)
+	}
+
+	return a.Cmp(b)
+}
+
+func (ref *Amount) Equals(other *Amount) bool {
+	return ref.Cmp(other) == 0
+}
+
+func scaleAmountValue(value *big.Int, digits uint8) *big.Int {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+
+	return scale.Mul(scale, value)
+}
+
+type amountDTO struct {
+	Value        string `json:"value"`
+	Divisibility uint8  `json:"divisibility"`
+}
+
+func (ref *Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&amountDTO{
+		Value:        ref.String(),
+		Divisibility: ref.divisibility,
+	})
+}
+
+func (ref *Amount) UnmarshalJSON(data []byte) error {
+	dto := &amountDTO{}
+
+	if err := json.Unmarshal(data, dto); err != nil {
+		return err
+	}
+
+	amount, err := ParseAmount(dto.Value, dto.Divisibility, RoundExact)
+	if err != nil {
+		return err
+	}
+
+	*ref = *amount
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	a, err := ParseAmount("1234.56789", 6, RoundExact)
+	assert.Nil(t, err)
+	assert.Equal(t, "1234.567890", a.String())
+	assert.Equal(t, uint64(1234567890), a.Uint64())
+
+	a, err = ParseAmount(".5", 2, RoundExact)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.50", a.String())
+
+	_, err = ParseAmount("1.2345", 2, RoundExact)
+	assert.Equal(t, ErrAmountPrecision, err)
+
+	a, err = ParseAmount("1.2345", 2, RoundDown)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.23", a.String())
+
+	a, err = ParseAmount("1.2301", 2, RoundUp)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.24", a.String())
+
+	a, err = ParseAmount("1.235", 2, RoundHalfUp)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.24", a.String())
+
+	_, err = ParseAmount("1,5", 2, RoundExact)
+	assert.Equal(t, ErrAmountInvalidFormat, err)
+
+	_, err = ParseAmount("18446744073709551616", 0, RoundExact)
+	assert.Equal(t, ErrAmountOverflow, err)
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	a := NewAmountFromUint64(150, 2)
+	b := NewAmountFromUint64(50, 2)
+
+	sum, err := a.Add(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "2.00", sum.String())
+
+	_, err = b.Sub(a)
+	assert.Equal(t, ErrAmountOverflow, err)
+
+	_, err = NewAmountFromUint64(math.MaxUint64, 0).Mul(2)
+	assert.Equal(t, ErrAmountOverflow, err)
+
+	_, err = a.Add(NewAmountFromUint64(1, 3))
+	assert.Equal(t, ErrAmountDivisibilityMismatch, err)
+
+	assert.True(t, a.Equals(NewAmountFromUint64(1500, 3)))
+	assert.Equal(t, 1, a.Cmp(b))
+}
+
+func TestAmount_JSON(t *testing.T) {
+	a, err := NewAmount(big.NewInt(1234567), 6)
+	assert.Nil(t, err)
+
+	b, err := json.Marshal(a)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"value":"1.234567","divisibility":6}`, string(b))
+
+	got := &Amount{}
+	assert.Nil(t, json.Unmarshal(b, got))
+	assert.Equal(t, a, got)
+}
+
+func TestAmount_ZeroValue(t *testing.T) {
+	var zero Amount
+
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, uint64(0), zero.Uint64())
+	assert.Equal(t, 0, zero.Value().Sign())
+	assert.Equal(t, -1, zero.Cmp(NewAmountFromUint64(1, 0)))
+	assert.True(t, zero.Equals(NewAmountFromUint64(0, 6)))
+
+	sum, err := zero.Add(NewAmountFromUint64(5, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "5", sum.String())
+
+	_, err = zero.Sub(NewAmountFromUint64(1, 0))
+	assert.Equal(t, ErrAmountOverflow, err)
+
+	product, err := zero.Mul(10)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", product.String())
+
+	holder := &struct{ A Amount }{}
+	b, err := json.Marshal(holder)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"A":{"value":"0","divisibility":0}}`, string(b))
+	assert.Nil(t, json.Unmarshal(b, holder))
+	assert.True(t, holder.A.Equals(&zero))
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/base32"
+	"hash"
+	"strings"
+)
+
+// AddressChecksumSize is count of hash bytes appended to address
+const AddressChecksumSize = 4
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Base32Encode encodes b with RFC 4648 base32 alphabet and padding
+func Base32Encode(b []byte) string {
+	return base32.StdEncoding.EncodeToString(b)
+}
+
+// Base32Decode decodes RFC 4648 base32 string, padding is optional and case is ignored
+func Base32Decode(s string) ([]byte, error) {
+	return base32NoPadding.DecodeString(strings.TrimRight(strings.ToUpper(s), "="))
+}
+
+// EncodeAddress returns base32 address built from network byte, payload and checksum.
+// Checksum is the first AddressChecksumSize bytes of newHash() over network byte and payload,
+// catapult uses SHA3-256 there
+func EncodeAddress(network byte, payload []byte, newHash func() hash.Hash) string {
+	raw := make([]byte, 0, 1+len(payload)+AddressChecksumSize)
+	raw = append(raw, network)
+	raw = append(raw, payload...)
+	raw = append(raw, addressChecksum(raw, newHash)...)
+
+	return base32NoPadding.EncodeToString(raw)
+}
+
+// DecodeAddress validates checksum of base32 address and returns its network byte and payload.
+// Dashes used in pretty printed addresses are ignored
+func DecodeAddress(address string, newHash func() hash.Hash) (byte, []byte, error) {
+	raw, err := Base32Decode(strings.Replace(address, "-", "", -1))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(raw) <= 1+AddressChecksumSize {
+		return 0, nil, ErrAddressInvalidSize
+	}
+
+	body, checksum := raw[:len(raw)-AddressChecksumSize], raw[len(raw)-AddressChecksumSize:]
+
+	if !bytes.Equal(checksum, addressChecksum(body, newHash)) {
+		return 0, nil, ErrAddressChecksum
+	}
+
+	return body[0], body[1:], nil
+}
+
+// ValidateAddress checks checksum and network byte of base32 address
+func ValidateAddress(address string, network byte, newHash func() hash.Hash) error {
+	addrNetwork, _, err := DecodeAddress(address, newHash)
+	if err != nil {
+		return err
+	}
+
+	if addrNetwork != network {
+		return ErrAddressNetwork
+	}
+
+	return nil
+}
+
+func addressChecksum(body []byte, newHash func() hash.Hash) []byte {
+	h := newHash()
+	h.Write(body)
+
+	return h.Sum(nil)[:AddressChecksumSize]
+}
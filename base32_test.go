@@ -0,0 +1,38 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBase32(t *testing.T) {
+	assert.Equal(t, "MZXW6YQ=", Base32Encode([]byte("foob")))
+
+	b, err := Base32Decode("mzxw6yq")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("foob"), b)
+}
+
+func TestAddress(t *testing.T) {
+	payload := make([]byte, 20)
+	address := EncodeAddress(0xb8, payload, sha256.New)
+
+	assert.Len(t, address, 40)
+
+	network, got, err := DecodeAddress(address, sha256.New)
+	assert.Nil(t, err)
+	assert.Equal(t, byte(0xb8), network)
+	assert.Equal(t, payload, got)
+
+	assert.Nil(t, ValidateAddress(address[:6]+"-"+address[6:], 0xb8, sha256.New))
+	assert.Equal(t, ErrAddressNetwork, ValidateAddress(address, 0x68, sha256.New))
+
+	broken := []byte(address)
+	broken[10] = 'A' + (broken[10]-'A'+1)%26
+	assert.Equal(t, ErrAddressChecksum, ValidateAddress(string(broken), 0xb8, sha256.New))
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"fmt"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Indexes = func() [256]int8 {
+	var indexes [256]int8
+
+	for i := range indexes {
+		indexes[i] = -1
+	}
+
+	for i := 0; i < len(base58Alphabet); i++ {
+		indexes[base58Alphabet[i]] = int8(i)
+	}
+
+	return indexes
+}()
+
+// InvalidBase58CharError describes a character which is not in Bitcoin base58 alphabet
+type InvalidBase58CharError struct {
+	Offset int
+	Char   byte
+}
+
+func (ref *InvalidBase58CharError) Error() string {
+	return fmt.Sprintf("invalid base58 character %q at offset %d", ref.Char, ref.Offset)
+}
+
+// Base58Encode encodes b with Bitcoin base58 alphabet
+func Base58Encode(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	// log(256) / log(58) is less than 138 / 100
+	digits := make([]byte, (len(b)-zeros)*138/100+1)
+	size := 0
+
+	for _, v := range b[zeros:] {
+		carry := int(v)
+
+		for i := 0; i < size || carry != 0; i++ {
+			carry += 256 * int(digits[i])
+			digits[i] = byte(carry % 58)
+			carry /= 58
+
+			if i >= size {
+				size = i + 1
+			}
+		}
+	}
+
+	out := make([]byte, zeros+size)
+	for i := 0; i < zeros; i++ {
+		out[i] = base58Alphabet[0]
+	}
+
+	for i := 0; i < size; i++ {
+		out[zeros+i] = base58Alphabet[digits[size-1-i]]
+	}
+
+	return string(out)
+}
+
+// Base58Decode decodes Bitcoin base58 string
+func Base58Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// log(58) / log(256) is less than 733 / 1000
+	bytes := make([]byte, (len(s)-zeros)*733/1000+1)
+	size := 0
+
+	for i := zeros; i < len(s); i++ {
+		carry := int(base58Indexes[s[i]])
+		if carry < 0 {
+			return nil, &InvalidBase58CharError{Offset: i, Char: s[i]}
+		}
+
+		for j := 0; j < size || carry != 0; j++ {
+			carry += 58 * int(bytes[j])
+			bytes[j] = byte(carry)
+			carry >>= 8
+
+			if j >= size {
+				size = j + 1
+			}
+		}
+	}
+
+	out := make([]byte, zeros+size)
+	for i := 0; i < size; i++ {
+		out[zeros+i] = bytes[size-1-i]
+	}
+
+	return out, nil
+}
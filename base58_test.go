@@ -0,0 +1,35 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	cases := map[string]string{
+		"":             "",
+		"00":           "1",
+		"0000":         "11",
+		"61":           "2g",
+		"626262":       "a3gV",
+		"572e4794":     "3EFU7m",
+		"0000287fb4cd": "11233QC4",
+		"000111d38e5fc9071ffcd20b4a763cc9ae4f252bb4e48fd66a835e252ada93ff480d6dd43dc62a641155a5": base58Alphabet,
+	}
+
+	for h, s := range cases {
+		b := MustHexDecodeString(h)
+		assert.Equal(t, s, Base58Encode(b))
+
+		got, err := Base58Decode(s)
+		assert.Nil(t, err)
+		assert.Equal(t, b, got)
+	}
+
+	_, err := Base58Decode("12O")
+	assert.Equal(t, &InvalidBase58CharError{Offset: 2, Char: 'O'}, err)
+}
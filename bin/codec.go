@@ -0,0 +1,666 @@
+package bin
+
+import (
+	"encoding/binary"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/proximax-storage/go-xpx-utils"
+)
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	bigIntPtrType = reflect.TypeOf(&big.Int{})
+)
+
+// Marshal serializes fields with `bin` tag in declaration order.
+// Fields without tag and unexported fields are skipped.
+//
+// Tag format is `kind[,le|be][,len=N][,size=N][,prefix=uN][,count=Field][,elem=kind][,if=Field[==N]]`
+// where kind is one of u8, u16, u32, u64, bigint, bytes, struct, slice. Kind may be omitted when it
+// can be derived from the field type. Slices need len, prefix or count option, if option skips
+// field when referenced field is zero or is not equal to N
+func Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, ErrNotStruct
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	w := utils.NewBinaryWriter(0)
+
+	if err := marshalStruct(w, rv, rv.Type().Name()); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// Unmarshal deserializes data into struct pointed by v. All bytes of data must be consumed
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+
+	r := utils.NewBinaryReader(data)
+
+	if err := unmarshalStruct(r, rv.Elem(), rv.Elem().Type().Name()); err != nil {
+		return err
+	}
+
+	if r.Len() != 0 {
+		return ErrUnexpectedTrailer
+	}
+
+	return nil
+}
+
+func wrapFieldError(path string, err error) error {
+	if _, ok := err.(*FieldError); ok {
+		return err
+	}
+
+	return &FieldError{Field: path, Err: err}
+}
+
+type structField struct {
+	index int
+	path  string
+	info  *tagInfo
+}
+
+func taggedFields(v reflect.Value, path string) ([]*structField, error) {
+	t := v.Type()
+	fields := make([]*structField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag, ok := sf.Tag.Lookup(tagName)
+		if !ok || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		fieldPath := path + "." + sf.Name
+
+		info, err := parseTag(tag)
+		if err != nil {
+			return nil, wrapFieldError(fieldPath, err)
+		}
+
+		// count and if fields are read by Unmarshal before the field which references them
+		for _, name := range []string{info.count, info.condField} {
+			if len(name) == 0 {
+				continue
+			}
+
+			ref, ok := t.FieldByName(name)
+			if !ok {
+				return nil, wrapFieldError(fieldPath, ErrUnknownField)
+			}
+
+			if len(ref.Index) != 1 || ref.Index[0] >= i {
+				return nil, wrapFieldError(fieldPath, ErrForwardReference)
+			}
+		}
+
+		fields = append(fields, &structField{index: i, path: fieldPath, info: info})
+	}
+
+	return fields, nil
+}
+
+func marshalStruct(w *utils.BinaryWriter, v reflect.Value, path string) error {
+	fields, err := taggedFields(v, path)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		present, err := isPresent(v, field.info)
+		if err != nil {
+			return wrapFieldError(field.path, err)
+		}
+
+		if !present {
+			continue
+		}
+
+		if err := marshalValue(w, v, v.Field(field.index), field.info, field.path); err != nil {
+			return wrapFieldError(field.path, err)
+		}
+	}
+
+	return nil
+}
+
+func unmarshalStruct(r *utils.BinaryReader, v reflect.Value, path string) error {
+	fields, err := taggedFields(v, path)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		present, err := isPresent(v, field.info)
+		if err != nil {
+			return wrapFieldError(field.path, err)
+		}
+
+		if !present {
+			continue
+		}
+
+		if err := unmarshalValue(r, v, v.Field(field.index), field.info, field.path); err != nil {
+			return wrapFieldError(field.path, err)
+		}
+	}
+
+	return nil
+}
+
+func marshalValue(w *utils.BinaryWriter, parent, fv reflect.Value, info *tagInfo, path string) error {
+	kind, err := resolveKind(info.kind, fv.Type())
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case kindU8, kindU16, kindU32, kindU64:
+		size := kindSizes[kind]
+
+		u, err := uintValue(fv, size)
+		if err != nil {
+			return err
+		}
+
+		writeUint(w, u, size, info.order)
+
+	case kindBigInt:
+		if info.size <= 0 {
+			return ErrMissingSize
+		}
+
+		bi, err := bigIntValue(fv)
+		if err != nil {
+			return err
+		}
+
+		if bi.Sign() < 0 || bi.BitLen() > info.size*8 {
+			return ErrValueOverflow
+		}
+
+		if info.order == binary.BigEndian {
+			w.WriteBytes(utils.BigIntToByteArrayBE(bi, info.size))
+		} else {
+			w.WriteBigInt(bi, info.size)
+		}
+
+	case kindBytes:
+		if !isByteSequence(fv.Type()) {
+			return ErrUnsupportedType
+		}
+
+		if err := writeLength(w, parent, fv.Len(), fv.Kind() == reflect.Array, info); err != nil {
+			return err
+		}
+
+		b := make([]byte, fv.Len())
+		reflect.Copy(reflect.ValueOf(b), fv)
+		w.WriteBytes(b)
+
+	case kindStruct:
+		if fv.Kind() == reflect.Ptr {
+			// nil has no binary form, optional struct should be declared with if= condition
+			if fv.IsNil() {
+				return ErrNilStruct
+			}
+
+			fv = fv.Elem()
+		}
+
+		if fv.Kind() != reflect.Struct {
+			return ErrUnsupportedType
+		}
+
+		return marshalStruct(w, fv, path)
+
+	case kindSlice:
+		if fv.Kind() != reflect.Slice {
+			return ErrUnsupportedType
+		}
+
+		if err := writeLength(w, parent, fv.Len(), false, info); err != nil {
+			return err
+		}
+
+		elemInfo := info.elemInfo()
+
+		for i := 0; i < fv.Len(); i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+
+			if err := marshalValue(w, fv, fv.Index(i), elemInfo, elemPath); err != nil {
+				return wrapFieldError(elemPath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func unmarshalValue(r *utils.BinaryReader, parent, fv reflect.Value, info *tagInfo, path string) error {
+	kind, err := resolveKind(info.kind, fv.Type())
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case kindU8, kindU16, kindU32, kindU64:
+		size := kindSizes[kind]
+
+		u, err := readUint(r, size, info.order)
+		if err != nil {
+			return err
+		}
+
+		return setUint(fv, u, size)
+
+	case kindBigInt:
+		if info.size <= 0 {
+			return ErrMissingSize
+		}
+
+		var bi *big.Int
+
+		if info.order == binary.BigEndian {
+			b, err := r.ReadBytes(info.size)
+			if err != nil {
+				return err
+			}
+
+			bi = utils.BytesToBigIntegerBE(b)
+		} else if bi, err = r.ReadBigInt(info.size); err != nil {
+			return err
+		}
+
+		switch fv.Type() {
+		case bigIntPtrType:
+			fv.Set(reflect.ValueOf(bi))
+		case bigIntType:
+			fv.Set(reflect.ValueOf(bi).Elem())
+		default:
+			return ErrUnsupportedType
+		}
+
+	case kindBytes:
+		if !isByteSequence(fv.Type()) {
+			return ErrUnsupportedType
+		}
+
+		isArray := fv.Kind() == reflect.Array
+
+		n, err := readLength(r, parent, fv.Len(), isArray, info, 1)
+		if err != nil {
+			return err
+		}
+
+		b, err := r.ReadBytes(n)
+		if err != nil {
+			return err
+		}
+
+		if !isArray {
+			fv.Set(reflect.MakeSlice(fv.Type(), n, n))
+		}
+
+		reflect.Copy(fv, reflect.ValueOf(b))
+
+	case kindStruct:
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+
+			fv = fv.Elem()
+		}
+
+		if fv.Kind() != reflect.Struct {
+			return ErrUnsupportedType
+		}
+
+		return unmarshalStruct(r, fv, path)
+
+	case kindSlice:
+		if fv.Kind() != reflect.Slice {
+			return ErrUnsupportedType
+		}
+
+		elemInfo := info.elemInfo()
+
+		n, err := readLength(r, parent, 0, false, info, minSize(elemInfo, fv.Type().Elem()))
+		if err != nil {
+			return err
+		}
+
+		fv.Set(reflect.MakeSlice(fv.Type(), n, n))
+
+		for i := 0; i < n; i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+
+			if err := unmarshalValue(r, fv, fv.Index(i), elemInfo, elemPath); err != nil {
+				return wrapFieldError(elemPath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ref *tagInfo) elemInfo() *tagInfo {
+	return &tagInfo{
+		kind:  ref.elem,
+		order: ref.order,
+		size:  ref.size,
+	}
+}
+
+func resolveKind(kind string, t reflect.Type) (string, error) {
+	if len(kind) != 0 {
+		switch kind {
+		case kindU8, kindU16, kindU32, kindU64, kindBigInt, kindBytes, kindStruct, kindSlice:
+			return kind, nil
+		}
+
+		return "", ErrUnknownKind
+	}
+
+	if t == bigIntType || t == bigIntPtrType {
+		return kindBigInt, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Int8, reflect.Bool:
+		return kindU8, nil
+	case reflect.Uint16, reflect.Int16:
+		return kindU16, nil
+	case reflect.Uint32, reflect.Int32:
+		return kindU32, nil
+	case reflect.Uint64, reflect.Int64:
+		return kindU64, nil
+	case reflect.Array, reflect.Slice:
+		if isByteSequence(t) {
+			return kindBytes, nil
+		}
+
+		if t.Kind() == reflect.Slice {
+			return kindSlice, nil
+		}
+	case reflect.Struct:
+		return kindStruct, nil
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.Struct {
+			return kindStruct, nil
+		}
+	}
+
+	return "", ErrUnsupportedType
+}
+
+func isByteSequence(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+func isPresent(parent reflect.Value, info *tagInfo) (bool, error) {
+	if len(info.condField) == 0 {
+		return true, nil
+	}
+
+	v, err := fieldUint(parent, info.condField)
+	if err != nil {
+		return false, err
+	}
+
+	if info.condValue == nil {
+		return v != 0, nil
+	}
+
+	return v == *info.condValue, nil
+}
+
+func fieldUint(parent reflect.Value, name string) (uint64, error) {
+	if parent.Kind() != reflect.Struct {
+		return 0, ErrUnknownField
+	}
+
+	f := parent.FieldByName(name)
+	if !f.IsValid() {
+		return 0, ErrUnknownField
+	}
+
+	return uintValue(f, 8)
+}
+
+func writeLength(w *utils.BinaryWriter, parent reflect.Value, n int, isArray bool, info *tagInfo) error {
+	switch {
+	case isArray || info.length > 0:
+		if info.length > 0 && info.length != n {
+			return ErrLengthMismatch
+		}
+	case len(info.prefix) != 0:
+		size := kindSizes[info.prefix]
+		if size < 8 && uint64(n)>>(uint(size)*8) != 0 {
+			return ErrValueOverflow
+		}
+
+		writeUint(w, uint64(n), size, info.order)
+	case len(info.count) != 0:
+		count, err := fieldUint(parent, info.count)
+		if err != nil {
+			return err
+		}
+
+		if count != uint64(n) {
+			return ErrCountMismatch
+		}
+	default:
+		return ErrMissingLength
+	}
+
+	return nil
+}
+
+// readLength reads count of elements, count bigger than remaining bytes allow for elements of elemSize
+// fails early. Elements which may take no bytes have zero elemSize
+func readLength(r *utils.BinaryReader, parent reflect.Value, arrayLen int, isArray bool, info *tagInfo, elemSize int) (int, error) {
+	var n uint64
+
+	switch {
+	case isArray:
+		if info.length > 0 && info.length != arrayLen {
+			return 0, ErrLengthMismatch
+		}
+
+		return arrayLen, nil
+	case info.length > 0:
+		return info.length, nil
+	case len(info.prefix) != 0:
+		var err error
+
+		if n, err = readUint(r, kindSizes[info.prefix], info.order); err != nil {
+			return 0, err
+		}
+	case len(info.count) != 0:
+		var err error
+
+		if n, err = fieldUint(parent, info.count); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, ErrMissingLength
+	}
+
+	if elemSize > 0 && n > uint64(r.Len()/elemSize) {
+		return 0, &utils.ShortBufferError{Offset: r.Offset(), Need: int(n) * elemSize, Have: r.Len()}
+	}
+
+	return int(n), nil
+}
+
+// minSize returns minimal count of bytes taken by value of type t, structs and slices may take none
+func minSize(info *tagInfo, t reflect.Type) int {
+	kind, err := resolveKind(info.kind, t)
+	if err != nil {
+		return 0
+	}
+
+	switch {
+	case isIntKind(kind):
+		return kindSizes[kind]
+	case kind == kindBigInt:
+		return info.size
+	case kind == kindBytes && info.length > 0:
+		return info.length
+	case kind == kindBytes && len(info.prefix) != 0:
+		return kindSizes[info.prefix]
+	}
+
+	return 0
+}
+
+func uintValue(fv reflect.Value, size int) (uint64, error) {
+	bits := uint(size) * 8
+
+	switch fv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := fv.Uint()
+		if bits < 64 && u>>bits != 0 {
+			return 0, ErrValueOverflow
+		}
+
+		return u, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := fv.Int()
+		if bits < 64 && (i < -(1<<(bits-1)) || i >= 1<<(bits-1)) {
+			return 0, ErrValueOverflow
+		}
+
+		u := uint64(i)
+		if bits < 64 {
+			u &= 1<<bits - 1
+		}
+
+		return u, nil
+	case reflect.Bool:
+		if fv.Bool() {
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	return 0, ErrUnsupportedType
+}
+
+func setUint(fv reflect.Value, u uint64, size int) error {
+	switch fv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if fv.OverflowUint(u) {
+			return ErrValueOverflow
+		}
+
+		fv.SetUint(u)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		shift := uint(64 - size*8)
+		i := int64(u<<shift) >> shift
+
+		if fv.OverflowInt(i) {
+			return ErrValueOverflow
+		}
+
+		fv.SetInt(i)
+	case reflect.Bool:
+		fv.SetBool(u != 0)
+	default:
+		return ErrUnsupportedType
+	}
+
+	return nil
+}
+
+func bigIntValue(fv reflect.Value) (*big.Int, error) {
+	switch fv.Type() {
+	case bigIntPtrType:
+		if fv.IsNil() {
+			return new(big.Int), nil
+		}
+
+		return fv.Interface().(*big.Int), nil
+	case bigIntType:
+		bi := fv.Interface().(big.Int)
+
+		return &bi, nil
+	}
+
+	return nil, ErrUnsupportedType
+}
+
+func writeUint(w *utils.BinaryWriter, u uint64, size int, order binary.ByteOrder) {
+	if order == binary.BigEndian {
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, u)
+		w.WriteBytes(b[8-size:])
+
+		return
+	}
+
+	switch size {
+	case 1:
+		w.WriteUint8(uint8(u))
+	case 2:
+		w.WriteUint16(uint16(u))
+	case 4:
+		w.WriteUint32(uint32(u))
+	default:
+		w.WriteUint64(u)
+	}
+}
+
+func readUint(r *utils.BinaryReader, size int, order binary.ByteOrder) (uint64, error) {
+	if order == binary.BigEndian {
+		b, err := r.ReadBytes(size)
+		if err != nil {
+			return 0, err
+		}
+
+		var u uint64
+		for _, v := range b {
+			u = u<<8 | uint64(v)
+		}
+
+		return u, nil
+	}
+
+	switch size {
+	case 1:
+		v, err := r.ReadUint8()
+		return uint64(v), err
+	case 2:
+		v, err := r.ReadUint16()
+		return uint64(v), err
+	case 4:
+		v, err := r.ReadUint32()
+		return uint64(v), err
+	default:
+		return r.ReadUint64()
+	}
+}
@@ -0,0 +1,159 @@
+package bin
+
+import (
+	"github.com/proximax-storage/go-xpx-utils"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+type testMosaic struct {
+	Id     uint64   `bin:"u64"`
+	Amount *big.Int `bin:"bigint,size=8"`
+}
+
+type testTransaction struct {
+	Size        uint32        `bin:"u32,le"`
+	Version     uint16        `bin:"u16,be"`
+	Signer      [4]byte       `bin:"bytes"`
+	Hash        []byte        `bin:"bytes,len=2"`
+	HasMessage  bool          `bin:"u8"`
+	Message     []byte        `bin:"bytes,prefix=u16,if=HasMessage"`
+	MosaicCount uint8         `bin:"u8"`
+	Mosaics     []*testMosaic `bin:"slice,count=MosaicCount"`
+	Fees        []uint32      `bin:"slice,prefix=u8,elem=u32"`
+	Delta       int16         `bin:"u16"`
+	Extra       uint8         `bin:"u8,if=Version==3"`
+	Ignored     string
+}
+
+func TestMarshal(t *testing.T) {
+	tx := &testTransaction{
+		Size:        1,
+		Version:     2,
+		Signer:      [4]byte{0xaa, 0xbb, 0xcc, 0xdd},
+		Hash:        []byte{0x01, 0x02},
+		HasMessage:  true,
+		Message:     []byte("hi"),
+		MosaicCount: 1,
+		Mosaics:     []*testMosaic{{Id: 7, Amount: big.NewInt(0x0102)}},
+		Fees:        []uint32{5},
+		Delta:       -2,
+		Extra:       9,
+	}
+
+	b, err := Marshal(tx)
+	assert.Nil(t, err)
+
+	want := "01000000" + "0002" + "aabbccdd" + "0102" + "01" + "0200" + "6869" + "01" +
+		"0700000000000000" + "0201000000000000" + "01" + "05000000" + "feff"
+	assert.Equal(t, utils.MustHexDecodeString(want), b)
+
+	got := &testTransaction{}
+	assert.Nil(t, Unmarshal(b, got))
+
+	tx.Extra = 0
+	assert.Equal(t, tx, got)
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	_, err := Marshal(&testTransaction{Hash: []byte{0x01}})
+	assert.Equal(t, &FieldError{Field: "testTransaction.Hash", Err: ErrLengthMismatch}, err)
+
+	_, err = Marshal(&testTransaction{Hash: []byte{0x01, 0x02}, MosaicCount: 2})
+	assert.Equal(t, &FieldError{Field: "testTransaction.Mosaics", Err: ErrCountMismatch}, err)
+
+	_, err = Marshal(&testTransaction{
+		Hash:        []byte{0x01, 0x02},
+		MosaicCount: 1,
+		Mosaics:     []*testMosaic{{Amount: new(big.Int).Lsh(big.NewInt(1), 64)}},
+	})
+	assert.Equal(t, &FieldError{Field: "testTransaction.Mosaics[0].Amount", Err: ErrValueOverflow}, err)
+
+	_, err = Marshal(1)
+	assert.Equal(t, ErrNotStruct, err)
+}
+
+func TestUnmarshal_ShortBuffer(t *testing.T) {
+	err := Unmarshal([]byte{0x01, 0x00}, &testTransaction{})
+	assert.Equal(t, &FieldError{
+		Field: "testTransaction.Size",
+		Err:   &utils.ShortBufferError{Offset: 0, Need: 4, Have: 2},
+	}, err)
+
+	assert.Equal(t, ErrNotStructPointer, Unmarshal(nil, testTransaction{}))
+}
+
+type testNode struct {
+	Value   uint8     `bin:"u8"`
+	HasNext bool      `bin:"u8"`
+	Next    *testNode `bin:"struct,if=HasNext"`
+}
+
+type testRequiredNode struct {
+	Value uint8             `bin:"u8"`
+	Next  *testRequiredNode `bin:"struct"`
+}
+
+func TestMarshal_NilStruct(t *testing.T) {
+	node := &testNode{Value: 1, HasNext: true, Next: &testNode{Value: 2}}
+
+	b, err := Marshal(node)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01, 0x01, 0x02, 0x00}, b)
+
+	got := &testNode{}
+	assert.Nil(t, Unmarshal(b, got))
+	assert.Equal(t, node, got)
+
+	_, err = Marshal(&testRequiredNode{Value: 1})
+	assert.Equal(t, &FieldError{Field: "testRequiredNode.Next", Err: ErrNilStruct}, err)
+}
+
+type testForwardCount struct {
+	Data []byte `bin:"bytes,count=N"`
+	N    uint8  `bin:"u8"`
+}
+
+type testForwardCondition struct {
+	Extra uint8 `bin:"u8,if=Flag"`
+	Flag  uint8 `bin:"u8"`
+}
+
+type testMissingReference struct {
+	Data []byte `bin:"bytes,count=Missing"`
+}
+
+func TestMarshal_ForwardReference(t *testing.T) {
+	_, err := Marshal(&testForwardCount{Data: []byte{0x02}, N: 1})
+	assert.Equal(t, &FieldError{Field: "testForwardCount.Data", Err: ErrForwardReference}, err)
+
+	err = Unmarshal([]byte{0x01, 0x01}, &testForwardCondition{})
+	assert.Equal(t, &FieldError{Field: "testForwardCondition.Extra", Err: ErrForwardReference}, err)
+
+	_, err = Marshal(&testMissingReference{})
+	assert.Equal(t, &FieldError{Field: "testMissingReference.Data", Err: ErrUnknownField}, err)
+}
+
+type testEmpty struct{}
+
+type testWithEmpty struct {
+	Items []testEmpty `bin:"slice,prefix=u8"`
+}
+
+func TestUnmarshal_ZeroSizeElements(t *testing.T) {
+	v := &testWithEmpty{Items: make([]testEmpty, 3)}
+
+	b, err := Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x03}, b)
+
+	got := &testWithEmpty{}
+	assert.Nil(t, Unmarshal(b, got))
+	assert.Equal(t, v, got)
+
+	err = Unmarshal([]byte{0x03, 0x01, 0x00}, &struct {
+		Items []uint16 `bin:"slice,prefix=u8"`
+	}{})
+	assert.Equal(t, &FieldError{Field: ".Items", Err: &utils.ShortBufferError{Offset: 1, Need: 6, Have: 2}}, err)
+}
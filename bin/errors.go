@@ -0,0 +1,33 @@
+package bin
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotStructPointer  = errors.New("value should be a non-nil pointer to struct")
+	ErrNotStruct         = errors.New("value should be a struct or a pointer to struct")
+	ErrUnknownKind       = errors.New("unknown bin kind")
+	ErrUnsupportedType   = errors.New("field type is not supported by bin kind")
+	ErrInvalidTag        = errors.New("bin tag is invalid")
+	ErrMissingSize       = errors.New("bigint kind requires size option")
+	ErrMissingLength     = errors.New("slice requires len, prefix or count option")
+	ErrLengthMismatch    = errors.New("field length does not match declared length")
+	ErrUnknownField      = errors.New("referenced field does not exist")
+	ErrForwardReference  = errors.New("referenced field should be declared before the field")
+	ErrCountMismatch     = errors.New("count field does not match slice length")
+	ErrValueOverflow     = errors.New("value does not fit into declared size")
+	ErrUnexpectedTrailer = errors.New("unexpected bytes after struct")
+	ErrNilStruct         = errors.New("struct pointer is nil, optional struct requires if option")
+)
+
+// FieldError describes which field failed to marshal or unmarshal
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (ref *FieldError) Error() string {
+	return fmt.Sprintf("bin: field %s: %s", ref.Field, ref.Err)
+}
@@ -0,0 +1,118 @@
+package bin
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+const (
+	tagName = "bin"
+
+	kindU8     = "u8"
+	kindU16    = "u16"
+	kindU32    = "u32"
+	kindU64    = "u64"
+	kindBigInt = "bigint"
+	kindBytes  = "bytes"
+	kindStruct = "struct"
+	kindSlice  = "slice"
+)
+
+var kindSizes = map[string]int{
+	kindU8:  1,
+	kindU16: 2,
+	kindU32: 4,
+	kindU64: 8,
+}
+
+type tagInfo struct {
+	kind   string
+	order  binary.ByteOrder
+	length int
+	size   int
+	prefix string
+	count  string
+	elem   string
+
+	condField string
+	condValue *uint64
+}
+
+func isIntKind(kind string) bool {
+	_, ok := kindSizes[kind]
+
+	return ok
+}
+
+// parseTag parses `kind[,le|be][,len=N][,size=N][,prefix=uN][,count=Field][,elem=kind][,if=Field[==N]]`
+func parseTag(tag string) (*tagInfo, error) {
+	parts := strings.Split(tag, ",")
+
+	info := &tagInfo{
+		kind:  strings.TrimSpace(parts[0]),
+		order: binary.LittleEndian,
+	}
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+
+		key, value := part, ""
+		if idx := strings.IndexByte(part, '='); idx >= 0 {
+			key, value = part[:idx], part[idx+1:]
+		}
+
+		var err error
+
+		switch key {
+		case "le":
+			info.order = binary.LittleEndian
+		case "be":
+			info.order = binary.BigEndian
+		case "len":
+			info.length, err = strconv.Atoi(value)
+		case "size":
+			info.size, err = strconv.Atoi(value)
+		case "prefix":
+			if !isIntKind(value) {
+				return nil, ErrInvalidTag
+			}
+
+			info.prefix = value
+		case "count":
+			info.count = value
+		case "elem":
+			info.elem = value
+		case "if":
+			err = info.parseCondition(value)
+		default:
+			return nil, ErrInvalidTag
+		}
+
+		if err != nil {
+			return nil, ErrInvalidTag
+		}
+	}
+
+	return info, nil
+}
+
+func (ref *tagInfo) parseCondition(cond string) error {
+	if idx := strings.Index(cond, "=="); idx >= 0 {
+		v, err := strconv.ParseUint(cond[idx+2:], 0, 64)
+		if err != nil {
+			return err
+		}
+
+		ref.condValue = &v
+		cond = cond[:idx]
+	}
+
+	if len(cond) == 0 {
+		return ErrInvalidTag
+	}
+
+	ref.condField = cond
+
+	return nil
+}
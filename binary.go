@@ -0,0 +1,188 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// ShortBufferError is returned by BinaryReader when fewer bytes are left than a read requires.
+type ShortBufferError struct {
+	Offset int
+	Need   int
+	Have   int
+}
+
+func (ref *ShortBufferError) Error() string {
+	return fmt.Sprintf("short buffer at offset %d: need %d bytes, have %d", ref.Offset, ref.Need, ref.Have)
+}
+
+// BinaryWriter builds little endian payloads and tracks the offset of written bytes.
+type BinaryWriter struct {
+	buf []byte
+}
+
+// NewBinaryWriter returns writer with preallocated capacity
+func NewBinaryWriter(capacity int) *BinaryWriter {
+	return &BinaryWriter{buf: make([]byte, 0, capacity)}
+}
+
+// Offset returns count of bytes written so far
+func (ref *BinaryWriter) Offset() int {
+	return len(ref.buf)
+}
+
+// Bytes returns written payload
+func (ref *BinaryWriter) Bytes() []byte {
+	return ref.buf
+}
+
+func (ref *BinaryWriter) WriteUint8(v uint8) {
+	ref.buf = append(ref.buf, v)
+}
+
+func (ref *BinaryWriter) WriteUint16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	ref.buf = append(ref.buf, b[:]...)
+}
+
+func (ref *BinaryWriter) WriteUint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	ref.buf = append(ref.buf, b[:]...)
+}
+
+func (ref *BinaryWriter) WriteUint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	ref.buf = append(ref.buf, b[:]...)
+}
+
+// WriteBigInt writes value as numBytes little endian bytes, same as BigIntToByteArray
+func (ref *BinaryWriter) WriteBigInt(value *big.Int, numBytes int) {
+	ref.buf = append(ref.buf, BigIntToByteArray(value, numBytes)...)
+}
+
+// WriteBytes writes raw fixed array without length prefix
+func (ref *BinaryWriter) WriteBytes(b []byte) {
+	ref.buf = append(ref.buf, b...)
+}
+
+// WriteBytesWithSize writes uint32 length prefix followed by bytes
+func (ref *BinaryWriter) WriteBytesWithSize(b []byte) {
+	ref.WriteUint32(uint32(len(b)))
+	ref.WriteBytes(b)
+}
+
+// BinaryReader reads little endian payloads and tracks the offset of read bytes.
+type BinaryReader struct {
+	buf    []byte
+	offset int
+}
+
+func NewBinaryReader(buf []byte) *BinaryReader {
+	return &BinaryReader{buf: buf}
+}
+
+// Offset returns count of bytes read so far
+func (ref *BinaryReader) Offset() int {
+	return ref.offset
+}
+
+// Len returns count of unread bytes
+func (ref *BinaryReader) Len() int {
+	return len(ref.buf) - ref.offset
+}
+
+func (ref *BinaryReader) next(n int) ([]byte, error) {
+	if n < 0 || ref.Len() < n {
+		return nil, &ShortBufferError{Offset: ref.offset, Need: n, Have: ref.Len()}
+	}
+
+	b := ref.buf[ref.offset : ref.offset+n]
+	ref.offset += n
+
+	return b, nil
+}
+
+func (ref *BinaryReader) ReadUint8() (uint8, error) {
+	b, err := ref.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+func (ref *BinaryReader) ReadUint16() (uint16, error) {
+	b, err := ref.next(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (ref *BinaryReader) ReadUint32() (uint32, error) {
+	b, err := ref.next(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (ref *BinaryReader) ReadUint64() (uint64, error) {
+	b, err := ref.next(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// ReadBigInt reads numBytes little endian bytes, same as BytesToBigInteger
+func (ref *BinaryReader) ReadBigInt(numBytes int) (*big.Int, error) {
+	b, err := ref.next(numBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return BytesToBigInteger(b), nil
+}
+
+// ReadBytes reads raw fixed array of n bytes. Returned slice is a copy
+func (ref *BinaryReader) ReadBytes(n int) ([]byte, error) {
+	b, err := ref.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, n)
+	copy(out, b)
+
+	return out, nil
+}
+
+// ReadBytesWithSize reads uint32 length prefix followed by bytes
+func (ref *BinaryReader) ReadBytesWithSize() ([]byte, error) {
+	start := ref.offset
+
+	size, err := ref.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ref.ReadBytes(int(size))
+	if err != nil {
+		ref.offset = start
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestBinaryWriterReader(t *testing.T) {
+	w := NewBinaryWriter(0)
+	w.WriteUint8(0x01)
+	w.WriteUint16(0x0203)
+	w.WriteUint32(0x04050607)
+	w.WriteUint64(0x08090a0b0c0d0e0f)
+	w.WriteBigInt(big.NewInt(0x1122), 8)
+	w.WriteBytesWithSize([]byte{0xaa, 0xbb})
+	w.WriteBytes([]byte{0xcc})
+
+	assert.Equal(t, 1+2+4+8+8+4+2+1, w.Offset())
+	assert.Equal(t, MustHexDecodeString("01030207060504"), w.Bytes()[:7])
+
+	r := NewBinaryReader(w.Bytes())
+
+	u8, err := r.ReadUint8()
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(0x01), u8)
+
+	u16, err := r.ReadUint16()
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(0x0203), u16)
+
+	u32, err := r.ReadUint32()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0x04050607), u32)
+
+	u64, err := r.ReadUint64()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0x08090a0b0c0d0e0f), u64)
+
+	bi, err := r.ReadBigInt(8)
+	assert.Nil(t, err)
+	assert.True(t, EqualsBigInts(big.NewInt(0x1122), bi))
+
+	b, err := r.ReadBytesWithSize()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xaa, 0xbb}, b)
+
+	b, err = r.ReadBytes(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xcc}, b)
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestBinaryReader_ShortBuffer(t *testing.T) {
+	r := NewBinaryReader([]byte{0x01, 0x02, 0x03})
+
+	_, err := r.ReadUint8()
+	assert.Nil(t, err)
+
+	_, err = r.ReadUint32()
+	assert.Equal(t, &ShortBufferError{Offset: 1, Need: 4, Have: 2}, err)
+	assert.Equal(t, 1, r.Offset())
+}
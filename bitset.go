@@ -0,0 +1,217 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"math/bits"
+)
+
+// BitOrder defines position of the bit inside of each byte
+type BitOrder int
+
+const (
+	// LSBFirst numbers bits from the least significant one, same as GetBit
+	LSBFirst BitOrder = iota
+	// MSBFirst numbers bits from the most significant one
+	MSBFirst
+)
+
+// BitSet is a set of bit flags over byte array
+type BitSet struct {
+	data  []byte
+	size  uint
+	order BitOrder
+}
+
+// NewBitSet returns zeroed bit set of size bits. Padding bits of the last byte are never set
+func NewBitSet(size uint, order BitOrder) *BitSet {
+	return &BitSet{
+		data:  make([]byte, (size+7)>>3),
+		size:  size,
+		order: order,
+	}
+}
+
+// BitSetFromBytes wraps byte array without copying it, size of bit set is all bits of data
+func BitSetFromBytes(data []byte, order BitOrder) *BitSet {
+	return &BitSet{
+		data:  data,
+		size:  uint(len(data)) << 3,
+		order: order,
+	}
+}
+
+// Bytes returns underlying byte array
+func (ref *BitSet) Bytes() []byte {
+	return ref.data
+}
+
+// Order returns bit order of bit set
+func (ref *BitSet) Order() BitOrder {
+	return ref.order
+}
+
+// Len returns size of bit set in bits
+func (ref *BitSet) Len() uint {
+	return ref.size
+}
+
+func (ref *BitSet) mask(i uint) byte {
+	if ref.order == MSBFirst {
+		return 0x80 >> (i & 7)
+	}
+
+	return 1 << (i & 7)
+}
+
+func (ref *BitSet) check(i uint) error {
+	if i >= ref.Len() {
+		return ErrBitIndexOutOfRange
+	}
+
+	return nil
+}
+
+// Get returns the i'th bit
+func (ref *BitSet) Get(i uint) (bool, error) {
+	if err := ref.check(i); err != nil {
+		return false, err
+	}
+
+	return ref.data[i>>3]&ref.mask(i) != 0, nil
+}
+
+// Set sets the i'th bit
+func (ref *BitSet) Set(i uint) error {
+	if err := ref.check(i); err != nil {
+		return err
+	}
+
+	ref.data[i>>3] |= ref.mask(i)
+
+	return nil
+}
+
+// Clear clears the i'th bit
+func (ref *BitSet) Clear(i uint) error {
+	if err := ref.check(i); err != nil {
+		return err
+	}
+
+	ref.data[i>>3] &^= ref.mask(i)
+
+	return nil
+}
+
+// Toggle inverts the i'th bit
+func (ref *BitSet) Toggle(i uint) error {
+	if err := ref.check(i); err != nil {
+		return err
+	}
+
+	ref.data[i>>3] ^= ref.mask(i)
+
+	return nil
+}
+
+// Count returns number of set bits
+func (ref *BitSet) Count() int {
+	count := 0
+
+	for _, b := range ref.data {
+		count += bits.OnesCount8(b)
+	}
+
+	return count
+}
+
+// ForEach calls fn for every set bit in ascending order until fn returns false
+func (ref *BitSet) ForEach(fn func(i uint) bool) {
+	for idx, b := range ref.data {
+		for b != 0 {
+			var pos uint
+
+			if ref.order == MSBFirst {
+				pos = uint(bits.LeadingZeros8(b))
+				b &^= 0x80 >> pos
+			} else {
+				pos = uint(bits.TrailingZeros8(b))
+				b &^= 1 << pos
+			}
+
+			if !fn(uint(idx)<<3 + pos) {
+				return
+			}
+		}
+	}
+}
+
+// SetBits returns indexes of all set bits in ascending order
+func (ref *BitSet) SetBits() []uint {
+	indexes := make([]uint, 0, ref.Count())
+
+	ref.ForEach(func(i uint) bool {
+		indexes = append(indexes, i)
+		return true
+	})
+
+	return indexes
+}
+
+// And returns new bit set with bits set in both sets
+func (ref *BitSet) And(other *BitSet) (*BitSet, error) {
+	return ref.combine(other, func(a, b byte) byte { return a & b })
+}
+
+// Or returns new bit set with bits set in any of sets
+func (ref *BitSet) Or(other *BitSet) (*BitSet, error) {
+	return ref.combine(other, func(a, b byte) byte { return a | b })
+}
+
+// Xor returns new bit set with bits set in exactly one of sets
+func (ref *BitSet) Xor(other *BitSet) (*BitSet, error) {
+	return ref.combine(other, func(a, b byte) byte { return a ^ b })
+}
+
+// Not returns new bit set with all bits inverted
+func (ref *BitSet) Not() *BitSet {
+	result := NewBitSet(ref.size, ref.order)
+
+	for i, b := range ref.data {
+		result.data[i] = ^b
+	}
+
+	result.clearPadding()
+
+	return result
+}
+
+func (ref *BitSet) combine(other *BitSet, op func(a, b byte) byte) (*BitSet, error) {
+	if ref.size != other.size {
+		return nil, ErrBitSetSizeMismatch
+	}
+
+	result := NewBitSet(ref.size, ref.order)
+
+	for i := range ref.data {
+		b := other.data[i]
+		if other.order != ref.order {
+			b = bits.Reverse8(b)
+		}
+
+		result.data[i] = op(ref.data[i], b)
+	}
+
+	result.clearPadding()
+
+	return result, nil
+}
+
+// clearPadding clears bits of the last byte which are out of bit set size
+func (ref *BitSet) clearPadding() {
+	for i := ref.size; i < uint(len(ref.data))<<3; i++ {
+		ref.data[i>>3] &^= ref.mask(i)
+	}
+}
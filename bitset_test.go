@@ -0,0 +1,92 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	bs := NewBitSet(16, LSBFirst)
+
+	assert.Nil(t, bs.Set(0))
+	assert.Nil(t, bs.Set(9))
+	assert.Nil(t, bs.Toggle(15))
+	assert.Equal(t, []byte{0x01, 0x82}, bs.Bytes())
+	assert.Equal(t, 1, GetBit(bs.Bytes(), 9))
+	assert.Equal(t, 3, bs.Count())
+	assert.Equal(t, []uint{0, 9, 15}, bs.SetBits())
+
+	assert.Nil(t, bs.Clear(9))
+	ok, err := bs.Get(9)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, ErrBitIndexOutOfRange, bs.Set(16))
+	_, err = bs.Get(16)
+	assert.Equal(t, ErrBitIndexOutOfRange, err)
+}
+
+func TestBitSet_MSBFirst(t *testing.T) {
+	bs := NewBitSet(8, MSBFirst)
+
+	assert.Nil(t, bs.Set(0))
+	assert.Nil(t, bs.Set(7))
+	assert.Equal(t, []byte{0x81}, bs.Bytes())
+
+	assert.Nil(t, bs.Set(1))
+	assert.Equal(t, []uint{0, 1, 7}, bs.SetBits())
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	a := BitSetFromBytes([]byte{0x0f}, LSBFirst)
+	b := BitSetFromBytes([]byte{0x3c}, LSBFirst)
+
+	and, err := a.And(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x0c}, and.Bytes())
+
+	or, err := a.Or(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x3f}, or.Bytes())
+
+	xor, err := a.Xor(b)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x33}, xor.Bytes())
+
+	assert.Equal(t, []byte{0xf0}, a.Not().Bytes())
+
+	msb := BitSetFromBytes([]byte{0xf0}, MSBFirst)
+	and, err = a.And(msb)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x0f}, and.Bytes())
+
+	_, err = a.Or(NewBitSet(16, LSBFirst))
+	assert.Equal(t, ErrBitSetSizeMismatch, err)
+}
+
+func TestBitSet_Padding(t *testing.T) {
+	bs := NewBitSet(10, LSBFirst)
+
+	assert.Equal(t, uint(10), bs.Len())
+	assert.Equal(t, ErrBitIndexOutOfRange, bs.Set(12))
+	assert.Nil(t, bs.Set(9))
+
+	not := bs.Not()
+	assert.Equal(t, 9, not.Count())
+	assert.Equal(t, []byte{0xff, 0x01}, not.Bytes())
+
+	msb := NewBitSet(10, MSBFirst).Not()
+	assert.Equal(t, 10, msb.Count())
+	assert.Equal(t, []byte{0xff, 0xc0}, msb.Bytes())
+
+	xor, err := bs.Xor(NewBitSet(10, LSBFirst).Not())
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{0, 1, 2, 3, 4, 5, 6, 7, 8}, xor.SetBits())
+
+	_, err = bs.And(NewBitSet(16, LSBFirst))
+	assert.Equal(t, ErrBitSetSizeMismatch, err)
+}
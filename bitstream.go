@@ -0,0 +1,172 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bufio"
+	"io"
+)
+
+const maxBitCount = 64
+
+// BitWriter writes N-bit unsigned values to io.Writer.
+// With MSBFirst order values are written from the most significant bit and bytes are filled from the most significant bit,
+// with LSBFirst order both start from the least significant bit
+type BitWriter struct {
+	w     io.Writer
+	order BitOrder
+	cur   byte
+	n     uint
+}
+
+func NewBitWriter(w io.Writer, order BitOrder) *BitWriter {
+	return &BitWriter{
+		w:     w,
+		order: order,
+	}
+}
+
+// WriteBits writes n lowest bits of v
+func (ref *BitWriter) WriteBits(v uint64, n uint) error {
+	if n > maxBitCount {
+		return ErrBitCountTooLarge
+	}
+
+	if n < maxBitCount && v>>n != 0 {
+		return ErrBitValueOverflow
+	}
+
+	for i := uint(0); i < n; i++ {
+		var bit uint64
+
+		if ref.order == MSBFirst {
+			bit = v >> (n - 1 - i) & 1
+		} else {
+			bit = v >> i & 1
+		}
+
+		if err := ref.WriteBit(bit == 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ref *BitWriter) WriteBit(bit bool) error {
+	if bit {
+		if ref.order == MSBFirst {
+			ref.cur |= 0x80 >> ref.n
+		} else {
+			ref.cur |= 1 << ref.n
+		}
+	}
+
+	ref.n++
+
+	if ref.n == 8 {
+		return ref.writeCurrent()
+	}
+
+	return nil
+}
+
+// Align pads current byte with zero bits and writes it
+func (ref *BitWriter) Align() error {
+	if ref.n == 0 {
+		return nil
+	}
+
+	return ref.writeCurrent()
+}
+
+// Flush writes partially filled byte. Use it after the last write
+func (ref *BitWriter) Flush() error {
+	return ref.Align()
+}
+
+func (ref *BitWriter) writeCurrent() error {
+	_, err := ref.w.Write([]byte{ref.cur})
+	ref.cur, ref.n = 0, 0
+
+	return err
+}
+
+// BitReader reads N-bit unsigned values from io.Reader in the same order as BitWriter writes them
+type BitReader struct {
+	r     io.ByteReader
+	order BitOrder
+	cur   byte
+	n     uint
+}
+
+func NewBitReader(r io.Reader, order BitOrder) *BitReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return &BitReader{
+		r:     br,
+		order: order,
+	}
+}
+
+// ReadBits reads n bits. io.ErrUnexpectedEOF is returned when stream ends in the middle of value
+func (ref *BitReader) ReadBits(n uint) (uint64, error) {
+	if n > maxBitCount {
+		return 0, ErrBitCountTooLarge
+	}
+
+	var v uint64
+
+	for i := uint(0); i < n; i++ {
+		bit, err := ref.ReadBit()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return 0, err
+		}
+
+		if !bit {
+			continue
+		}
+
+		if ref.order == MSBFirst {
+			v |= 1 << (n - 1 - i)
+		} else {
+			v |= 1 << i
+		}
+	}
+
+	return v, nil
+}
+
+func (ref *BitReader) ReadBit() (bool, error) {
+	if ref.n == 0 {
+		b, err := ref.r.ReadByte()
+		if err != nil {
+			return false, err
+		}
+
+		ref.cur, ref.n = b, 8
+	}
+
+	pos := 8 - ref.n
+	ref.n--
+
+	if ref.order == MSBFirst {
+		return ref.cur&(0x80>>pos) != 0, nil
+	}
+
+	return ref.cur&(1<<pos) != 0, nil
+}
+
+// Align discards remaining bits of current byte
+func (ref *BitReader) Align() {
+	ref.n = 0
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestBitWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewBitWriter(buf, MSBFirst)
+
+	assert.Nil(t, w.WriteBits(0x5, 3))
+	assert.Nil(t, w.WriteBits(0x1, 1))
+	assert.Nil(t, w.WriteBits(0x3f, 6))
+	assert.Nil(t, w.Flush())
+	assert.Equal(t, []byte{0xbf, 0xc0}, buf.Bytes())
+
+	buf.Reset()
+	w = NewBitWriter(buf, LSBFirst)
+
+	assert.Nil(t, w.WriteBits(0x5, 3))
+	assert.Nil(t, w.WriteBits(0x1, 1))
+	assert.Nil(t, w.WriteBits(0x3f, 6))
+	assert.Nil(t, w.Flush())
+	assert.Equal(t, []byte{0xfd, 0x03}, buf.Bytes())
+
+	assert.Equal(t, ErrBitValueOverflow, w.WriteBits(4, 2))
+	assert.Equal(t, ErrBitCountTooLarge, w.WriteBits(0, 65))
+}
+
+func TestBitReader(t *testing.T) {
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		buf := &bytes.Buffer{}
+		w := NewBitWriter(buf, order)
+
+		assert.Nil(t, w.WriteBits(0x5, 3))
+		assert.Nil(t, w.Align())
+		assert.Nil(t, w.WriteBits(math.MaxUint64, 64))
+		assert.Nil(t, w.WriteBits(0x2a, 7))
+		assert.Nil(t, w.Flush())
+
+		r := NewBitReader(buf, order)
+
+		v, err := r.ReadBits(3)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(0x5), v)
+
+		r.Align()
+
+		v, err = r.ReadBits(64)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(math.MaxUint64), v)
+
+		v, err = r.ReadBits(7)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(0x2a), v)
+
+		_, err = r.ReadBits(2)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+}
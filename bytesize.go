@@ -0,0 +1,181 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is count of bytes which is parsed from and formatted to human readable form like "64MiB" or "1.5 GB"
+type ByteSize uint64
+
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+type byteSizeUnit struct {
+	name string
+	size ByteSize
+}
+
+var (
+	siByteSizeUnits = []byteSizeUnit{
+		{"EB", EB}, {"PB", PB}, {"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB},
+	}
+	iecByteSizeUnits = []byteSizeUnit{
+		{"EiB", EiB}, {"PiB", PiB}, {"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB},
+	}
+	byteSizeUnits = map[string]ByteSize{
+		"": Byte, "B": Byte,
+		"K": KB, "KB": KB, "M": MB, "MB": MB, "G": GB, "GB": GB,
+		"T": TB, "TB": TB, "P": PB, "PB": PB, "E": EB, "EB": EB,
+		"KI": KiB, "KIB": KiB, "MI": MiB, "MIB": MiB, "GI": GiB, "GIB": GiB,
+		"TI": TiB, "TIB": TiB, "PI": PiB, "PIB": PiB, "EI": EiB, "EIB": EiB,
+	}
+)
+
+// ParseByteSize parses number with optional SI (KB, MB, ...) or IEC (KiB, MiB, ...) unit.
+// Units are case insensitive, single letter units like "M" are SI. Fractional bytes are truncated
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if idx < 0 {
+		idx = len(s)
+	}
+
+	number, unit := s[:idx], strings.ToUpper(strings.TrimSpace(s[idx:]))
+
+	if len(number) == 0 || strings.Count(number, ".") > 1 || number == "." {
+		return 0, ErrByteSizeInvalidFormat
+	}
+
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, ErrByteSizeInvalidFormat
+	}
+
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, ErrByteSizeInvalidFormat
+	}
+
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(multiplier))))
+
+	bytes := new(big.Int).Quo(value.Num(), value.Denom())
+	if !bytes.IsUint64() {
+		return 0, ErrByteSizeOverflow
+	}
+
+	return ByteSize(bytes.Uint64()), nil
+}
+
+// String returns IEC representation rounded to two decimals
+func (ref ByteSize) String() string {
+	return ref.FormatIEC()
+}
+
+// FormatIEC returns representation with binary units like "1.5GiB"
+func (ref ByteSize) FormatIEC() string {
+	return formatByteSize(ref, iecByteSizeUnits)
+}
+
+// FormatSI returns representation with decimal units like "1.5GB"
+func (ref ByteSize) FormatSI() string {
+	return formatByteSize(ref, siByteSizeUnits)
+}
+
+// formatByteSize picks the largest unit in which rounded value is at least 1, so values
+// just below unit boundary like 1MiB-1 are printed as "1MiB" instead of "1024KiB"
+func formatByteSize(size ByteSize, units []byteSizeUnit) string {
+	for _, unit := range units {
+		value := math.Round(float64(size)/float64(unit.size)*100) / 100
+
+		if value >= 1 {
+			formatted := strconv.FormatFloat(value, 'f', 2, 64)
+			formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+
+			return formatted + unit.name
+		}
+	}
+
+	return strconv.FormatUint(uint64(size), 10) + "B"
+}
+
+// MarshalText returns exact representation with the largest IEC or SI unit which divides size
+func (ref ByteSize) MarshalText() ([]byte, error) {
+	best := byteSizeUnit{"B", Byte}
+
+	if ref != 0 {
+		for _, units := range [][]byteSizeUnit{iecByteSizeUnits, siByteSizeUnits} {
+			for _, unit := range units {
+				if ref%unit.size == 0 && unit.size > best.size {
+					best = unit
+				}
+			}
+		}
+	}
+
+	return []byte(strconv.FormatUint(uint64(ref/best.size), 10) + best.name), nil
+}
+
+func (ref *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ref = size
+
+	return nil
+}
+
+func (ref ByteSize) MarshalJSON() ([]byte, error) {
+	text, err := ref.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON accepts human readable string or number of bytes
+func (ref *ByteSize) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n uint64
+
+		if err := json.Unmarshal(data, &n); err != nil {
+			return ErrByteSizeInvalidFormat
+		}
+
+		*ref = ByteSize(n)
+
+		return nil
+	}
+
+	return ref.UnmarshalText([]byte(s))
+}
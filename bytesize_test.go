@@ -0,0 +1,70 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]ByteSize{
+		"64MiB":  64 * MiB,
+		"1.5 GB": 1500 * MB,
+		"1.5gib": 1536 * MiB,
+		"10":     10,
+		"2 k":    2 * KB,
+		"0.5B":   0,
+	}
+
+	for s, want := range cases {
+		got, err := ParseByteSize(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, want, got, s)
+	}
+
+	_, err := ParseByteSize("16EiB")
+	assert.Equal(t, ErrByteSizeOverflow, err)
+
+	got, err := ParseByteSize("18446744073709551615.5")
+	assert.Nil(t, err)
+	assert.Equal(t, ByteSize(math.MaxUint64), got)
+
+	_, err = ParseByteSize("18446744073709551616")
+	assert.Equal(t, ErrByteSizeOverflow, err)
+
+	_, err = ParseByteSize("12 parsecs")
+	assert.Equal(t, ErrByteSizeInvalidFormat, err)
+
+	_, err = ParseByteSize("MB")
+	assert.Equal(t, ErrByteSizeInvalidFormat, err)
+}
+
+func TestByteSize_Format(t *testing.T) {
+	assert.Equal(t, "1.5GiB", (1536 * MiB).String())
+	assert.Equal(t, "1.61GB", (1536 * MiB).FormatSI())
+	assert.Equal(t, "512B", ByteSize(512).String())
+	assert.Equal(t, "1MiB", (MiB - 1).String())
+	assert.Equal(t, "1GB", (GB - 1).FormatSI())
+	assert.Equal(t, "1014KiB", (MiB - 10*KiB).String())
+	assert.Equal(t, "16EiB", ByteSize(math.MaxUint64).String())
+}
+
+func TestByteSize_JSON(t *testing.T) {
+	config := &struct {
+		Limit ByteSize `json:"limit"`
+		Chunk ByteSize `json:"chunk"`
+	}{}
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"limit":"1.5 GB","chunk":1048576}`), config))
+	assert.Equal(t, 1500*MB, config.Limit)
+	assert.Equal(t, MiB, config.Chunk)
+
+	b, err := json.Marshal(config)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"limit":"1500MB","chunk":"1MiB"}`, string(b))
+}
@@ -0,0 +1,242 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// OverflowError is returned by checked arithmetic when result does not fit into operands type
+type OverflowError struct {
+	Op string
+	A  interface{}
+	B  interface{}
+}
+
+func (ref *OverflowError) Error() string {
+	return fmt.Sprintf("%s overflow: %v, %v", ref.Op, ref.A, ref.B)
+}
+
+func AddUint64(a, b uint64) (uint64, error) {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 {
+		return 0, &OverflowError{Op: "add", A: a, B: b}
+	}
+
+	return sum, nil
+}
+
+func SubUint64(a, b uint64) (uint64, error) {
+	diff, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		return 0, &OverflowError{Op: "sub", A: a, B: b}
+	}
+
+	return diff, nil
+}
+
+func MulUint64(a, b uint64) (uint64, error) {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return 0, &OverflowError{Op: "mul", A: a, B: b}
+	}
+
+	return lo, nil
+}
+
+func DivUint64(a, b uint64) (uint64, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	return a / b, nil
+}
+
+func PowUint64(base, exp uint64) (uint64, error) {
+	result := uint64(1)
+
+	for i := uint64(0); i < exp; i++ {
+		var err error
+
+		if result, err = MulUint64(result, base); err != nil {
+			return 0, &OverflowError{Op: "pow", A: base, B: exp}
+		}
+
+		if result <= 1 {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func AddInt64(a, b int64) (int64, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, &OverflowError{Op: "add", A: a, B: b}
+	}
+
+	return sum, nil
+}
+
+func SubInt64(a, b int64) (int64, error) {
+	diff := a - b
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, &OverflowError{Op: "sub", A: a, B: b}
+	}
+
+	return diff, nil
+}
+
+func MulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	product := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || product/b != a {
+		return 0, &OverflowError{Op: "mul", A: a, B: b}
+	}
+
+	return product, nil
+}
+
+func DivInt64(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	if a == math.MinInt64 && b == -1 {
+		return 0, &OverflowError{Op: "div", A: a, B: b}
+	}
+
+	return a / b, nil
+}
+
+func PowInt64(base int64, exp uint64) (int64, error) {
+	switch {
+	case exp == 0:
+		return 1, nil
+	case base == 0 || base == 1:
+		return base, nil
+	case base == -1:
+		if exp%2 == 0 {
+			return 1, nil
+		}
+
+		return -1, nil
+	}
+
+	result := int64(1)
+
+	for i := uint64(0); i < exp; i++ {
+		var err error
+
+		if result, err = MulInt64(result, base); err != nil {
+			return 0, &OverflowError{Op: "pow", A: base, B: exp}
+		}
+	}
+
+	return result, nil
+}
+
+// SaturatingAddUint64 returns math.MaxUint64 on overflow
+func SaturatingAddUint64(a, b uint64) uint64 {
+	if sum, err := AddUint64(a, b); err == nil {
+		return sum
+	}
+
+	return math.MaxUint64
+}
+
+// SaturatingSubUint64 returns 0 on underflow
+func SaturatingSubUint64(a, b uint64) uint64 {
+	if diff, err := SubUint64(a, b); err == nil {
+		return diff
+	}
+
+	return 0
+}
+
+// SaturatingMulUint64 returns math.MaxUint64 on overflow
+func SaturatingMulUint64(a, b uint64) uint64 {
+	if product, err := MulUint64(a, b); err == nil {
+		return product
+	}
+
+	return math.MaxUint64
+}
+
+// SaturatingAddInt64 returns math.MaxInt64 or math.MinInt64 on overflow
+func SaturatingAddInt64(a, b int64) int64 {
+	if sum, err := AddInt64(a, b); err == nil {
+		return sum
+	}
+
+	if b > 0 {
+		return math.MaxInt64
+	}
+
+	return math.MinInt64
+}
+
+// SaturatingSubInt64 returns math.MaxInt64 or math.MinInt64 on overflow
+func SaturatingSubInt64(a, b int64) int64 {
+	if diff, err := SubInt64(a, b); err == nil {
+		return diff
+	}
+
+	if b < 0 {
+		return math.MaxInt64
+	}
+
+	return math.MinInt64
+}
+
+// SaturatingMulInt64 returns math.MaxInt64 or math.MinInt64 on overflow
+func SaturatingMulInt64(a, b int64) int64 {
+	if product, err := MulInt64(a, b); err == nil {
+		return product
+	}
+
+	if (a < 0) == (b < 0) {
+		return math.MaxInt64
+	}
+
+	return math.MinInt64
+}
+
+// AddUint64Big returns exact sum promoted to big.Int
+func AddUint64Big(a, b uint64) *big.Int {
+	return new(big.Int).Add(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+}
+
+// MulUint64Big returns exact product promoted to big.Int
+func MulUint64Big(a, b uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+}
+
+// PowUint64Big returns exact power promoted to big.Int
+func PowUint64Big(base, exp uint64) *big.Int {
+	return new(big.Int).Exp(new(big.Int).SetUint64(base), new(big.Int).SetUint64(exp), nil)
+}
+
+// AddInt64Big returns exact sum promoted to big.Int
+func AddInt64Big(a, b int64) *big.Int {
+	return new(big.Int).Add(big.NewInt(a), big.NewInt(b))
+}
+
+// SubInt64Big returns exact difference promoted to big.Int
+func SubInt64Big(a, b int64) *big.Int {
+	return new(big.Int).Sub(big.NewInt(a), big.NewInt(b))
+}
+
+// MulInt64Big returns exact product promoted to big.Int
+func MulInt64Big(a, b int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCheckedUint64(t *testing.T) {
+	v, err := AddUint64(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), v)
+
+	_, err = AddUint64(math.MaxUint64, 1)
+	assert.Equal(t, &OverflowError{Op: "add", A: uint64(math.MaxUint64), B: uint64(1)}, err)
+
+	_, err = SubUint64(1, 2)
+	assert.NotNil(t, err)
+
+	_, err = MulUint64(math.MaxUint32+1, math.MaxUint32+1)
+	assert.NotNil(t, err)
+
+	_, err = DivUint64(1, 0)
+	assert.Equal(t, ErrDivisionByZero, err)
+
+	v, err = PowUint64(10, 19)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1e19), v)
+
+	_, err = PowUint64(10, 20)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, uint64(math.MaxUint64), SaturatingAddUint64(math.MaxUint64, 1))
+	assert.Equal(t, uint64(0), SaturatingSubUint64(1, 2))
+	assert.Equal(t, "340282366920938463426481119284349108225", MulUint64Big(math.MaxUint64, math.MaxUint64).String())
+}
+
+func TestCheckedInt64(t *testing.T) {
+	_, err := AddInt64(math.MaxInt64, 1)
+	assert.NotNil(t, err)
+
+	_, err = SubInt64(math.MinInt64, 1)
+	assert.NotNil(t, err)
+
+	_, err = MulInt64(math.MinInt64, -1)
+	assert.NotNil(t, err)
+
+	_, err = DivInt64(math.MinInt64, -1)
+	assert.NotNil(t, err)
+
+	v, err := PowInt64(-2, 63)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MinInt64), v)
+
+	_, err = PowInt64(2, 63)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, int64(math.MinInt64), SaturatingSubInt64(math.MinInt64, 1))
+	assert.Equal(t, int64(math.MinInt64), SaturatingMulInt64(math.MaxInt64, -2))
+	assert.Equal(t, "-9223372036854775809", SubInt64Big(math.MinInt64, 1).String())
+}
@@ -0,0 +1,192 @@
+package cid
+
+import (
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/proximax-storage/go-xpx-utils"
+)
+
+// Content codecs from multicodec table
+const (
+	CodecRaw         uint64 = 0x55
+	CodecDagProtobuf uint64 = 0x70
+	CodecDagCBOR     uint64 = 0x71
+)
+
+// Multibase prefixes supported by Parse
+const (
+	MultibaseBase32      = 'b'
+	MultibaseBase32Upper = 'B'
+	MultibaseBase58BTC   = 'z'
+	MultibaseBase16      = 'f'
+	MultibaseBase16Upper = 'F'
+)
+
+const (
+	cidV0              = 0
+	cidV1              = 1
+	cidV0Length        = 46
+	cidV0Prefix        = "Qm"
+	cidV0BinaryLength  = 34
+	cidV0MultihashSize = 32
+)
+
+// Cid is IPFS content identifier
+type Cid struct {
+	Version uint64
+	Codec   uint64
+	Hash    *Multihash
+}
+
+// NewCidV0 returns CID v0 which must be sha2-256 multihash of dag-pb node
+func NewCidV0(mh *Multihash) (*Cid, error) {
+	if mh.Code != CodeSHA256 || len(mh.Digest) != cidV0MultihashSize {
+		return nil, ErrInvalidCidV0
+	}
+
+	return &Cid{
+		Version: cidV0,
+		Codec:   CodecDagProtobuf,
+		Hash:    mh,
+	}, nil
+}
+
+func NewCidV1(codec uint64, mh *Multihash) *Cid {
+	return &Cid{
+		Version: cidV1,
+		Codec:   codec,
+		Hash:    mh,
+	}
+}
+
+// Parse decodes CID v0 base58 string or CID v1 multibase string
+func Parse(s string) (*Cid, error) {
+	if len(s) == 0 {
+		return nil, ErrBlankCid
+	}
+
+	if len(s) == cidV0Length && strings.HasPrefix(s, cidV0Prefix) {
+		b, err := utils.Base58Decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return Decode(b)
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+
+	switch s[0] {
+	case MultibaseBase32, MultibaseBase32Upper:
+		b, err = utils.Base32Decode(s[1:])
+	case MultibaseBase58BTC:
+		b, err = utils.Base58Decode(s[1:])
+	case MultibaseBase16, MultibaseBase16Upper:
+		b, err = hex.DecodeString(s[1:])
+	default:
+		return nil, ErrUnsupportedMultibase
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(b)
+}
+
+// Decode parses binary CID
+func Decode(b []byte) (*Cid, error) {
+	if len(b) == cidV0BinaryLength && b[0] == byte(CodeSHA256) && b[1] == cidV0MultihashSize {
+		mh, err := ParseMultihash(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCidV0(mh)
+	}
+
+	version, n, err := utils.DecodeUvarint(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != cidV1 {
+		return nil, ErrUnsupportedCidVersion
+	}
+
+	codec, m, err := utils.DecodeUvarint(b[n:])
+	if err != nil {
+		return nil, err
+	}
+
+	n += m
+
+	mh, m, err := DecodeMultihash(b[n:])
+	if err != nil {
+		return nil, err
+	}
+
+	if n+m != len(b) {
+		return nil, ErrUnexpectedTrailingData
+	}
+
+	return NewCidV1(codec, mh), nil
+}
+
+// Bytes returns binary representation of CID
+func (ref *Cid) Bytes() []byte {
+	if ref.Version == cidV0 {
+		return ref.Hash.Bytes()
+	}
+
+	buf := utils.EncodeUvarint(ref.Version)
+	buf = append(buf, utils.EncodeUvarint(ref.Codec)...)
+
+	return append(buf, ref.Hash.Bytes()...)
+}
+
+// String returns canonical form: base58 for CID v0 and lower case base32 multibase for CID v1
+func (ref *Cid) String() string {
+	if ref.Version == cidV0 {
+		return ref.Hash.B58String()
+	}
+
+	encoded := strings.TrimRight(utils.Base32Encode(ref.Bytes()), "=")
+
+	return string(MultibaseBase32) + strings.ToLower(encoded)
+}
+
+// ToV1 returns CID v1 with the same codec and multihash
+func (ref *Cid) ToV1() *Cid {
+	return NewCidV1(ref.Codec, ref.Hash)
+}
+
+// Equals compares binary representations of CIDs
+func (ref *Cid) Equals(other *Cid) bool {
+	return other != nil && string(ref.Bytes()) == string(other.Bytes())
+}
+
+// Verify checks that CID addresses data hashed by newHash, digest must have full size of hash
+func (ref *Cid) Verify(data []byte, newHash func() hash.Hash) error {
+	return ref.Hash.Verify(data, newHash)
+}
+
+func (ref *Cid) MarshalText() ([]byte, error) {
+	return []byte(ref.String()), nil
+}
+
+func (ref *Cid) UnmarshalText(text []byte) error {
+	c, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ref = *c
+
+	return nil
+}
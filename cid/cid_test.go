@@ -0,0 +1,79 @@
+package cid
+
+import (
+	"crypto/sha256"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	testCidV0 = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+)
+
+func TestParse_V0(t *testing.T) {
+	c, err := Parse(testCidV0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), c.Version)
+	assert.Equal(t, CodecDagProtobuf, c.Codec)
+	assert.Equal(t, CodeSHA256, c.Hash.Code)
+	assert.Len(t, c.Hash.Digest, 32)
+	assert.Equal(t, testCidV0, c.String())
+
+	v1, err := Parse(c.ToV1().String())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), v1.Version)
+	assert.Equal(t, c.Hash, v1.Hash)
+}
+
+func TestCid_Verify(t *testing.T) {
+	data := []byte("hello world")
+	c := NewCidV1(CodecRaw, SumMultihash(CodeSHA256, data, sha256.New))
+
+	assert.Equal(t, "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e", c.String())
+
+	parsed, err := Parse(c.String())
+	assert.Nil(t, err)
+	assert.True(t, c.Equals(parsed))
+
+	assert.Nil(t, parsed.Verify(data, sha256.New))
+	assert.Equal(t, ErrDigestMismatch, parsed.Verify([]byte("hello"), sha256.New))
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse("")
+	assert.Equal(t, ErrBlankCid, err)
+
+	_, err = Parse("xabc")
+	assert.Equal(t, ErrUnsupportedMultibase, err)
+
+	_, err = Decode([]byte{0x02, 0x55, 0x12, 0x00})
+	assert.Equal(t, ErrUnsupportedCidVersion, err)
+
+	_, err = NewCidV0(NewMultihash(CodeSHA512, make([]byte, 32)))
+	assert.Equal(t, ErrInvalidCidV0, err)
+}
+
+func TestMultihash_Verify_Digests(t *testing.T) {
+	data := []byte("hello world")
+
+	c, err := Decode([]byte{0x01, 0x55, 0x12, 0x00})
+	assert.Nil(t, err)
+	assert.Equal(t, ErrEmptyDigest, c.Verify(data, sha256.New))
+	assert.Equal(t, ErrEmptyDigest, c.Hash.VerifyTruncated(data, sha256.New))
+
+	full := SumMultihash(CodeSHA256, data, sha256.New)
+	truncated := NewMultihash(CodeSHA256, full.Digest[:16])
+	assert.Equal(t, ErrDigestTruncated, truncated.Verify(data, sha256.New))
+	assert.Nil(t, truncated.VerifyTruncated(data, sha256.New))
+	assert.Equal(t, ErrDigestMismatch, truncated.VerifyTruncated([]byte("hello"), sha256.New))
+
+	long := NewMultihash(CodeSHA256, append(full.Digest, 0))
+	assert.Equal(t, ErrDigestTooLong, long.VerifyTruncated(data, sha256.New))
+}
+
+func TestDecode_TrailingData(t *testing.T) {
+	c := NewCidV1(CodecRaw, SumMultihash(CodeSHA256, []byte("hello world"), sha256.New))
+
+	_, err := Decode(append(c.Bytes(), 0x00))
+	assert.Equal(t, ErrUnexpectedTrailingData, err)
+}
@@ -0,0 +1,17 @@
+package cid
+
+import "errors"
+
+var (
+	ErrMultihashTooShort      = errors.New("multihash is too short")
+	ErrMultihashLength        = errors.New("multihash digest length does not match header")
+	ErrDigestMismatch         = errors.New("digest does not match data")
+	ErrDigestTooLong          = errors.New("digest is longer than hash size")
+	ErrDigestTruncated        = errors.New("digest is shorter than hash size")
+	ErrEmptyDigest            = errors.New("digest is empty")
+	ErrUnsupportedCidVersion  = errors.New("unsupported cid version")
+	ErrUnsupportedMultibase   = errors.New("unsupported multibase prefix")
+	ErrInvalidCidV0           = errors.New("cid v0 should be sha2-256 multihash with 32 bytes digest")
+	ErrBlankCid               = errors.New("cid is blank")
+	ErrUnexpectedTrailingData = errors.New("unexpected data after cid")
+)
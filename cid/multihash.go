@@ -0,0 +1,126 @@
+package cid
+
+import (
+	"bytes"
+	"hash"
+
+	"github.com/proximax-storage/go-xpx-utils"
+)
+
+// Multihash codes from multicodec table
+const (
+	CodeIdentity   uint64 = 0x00
+	CodeSHA1       uint64 = 0x11
+	CodeSHA256     uint64 = 0x12
+	CodeSHA512     uint64 = 0x13
+	CodeSHA3_512   uint64 = 0x14
+	CodeSHA3_256   uint64 = 0x16
+	CodeKeccak256  uint64 = 0x1b
+	CodeBlake2b256 uint64 = 0xb220
+)
+
+// Multihash is self describing digest: hash function code followed by digest length and digest
+type Multihash struct {
+	Code   uint64
+	Digest []byte
+}
+
+func NewMultihash(code uint64, digest []byte) *Multihash {
+	return &Multihash{
+		Code:   code,
+		Digest: digest,
+	}
+}
+
+// SumMultihash hashes data and wraps digest into multihash with provided code
+func SumMultihash(code uint64, data []byte, newHash func() hash.Hash) *Multihash {
+	h := newHash()
+	h.Write(data)
+
+	return NewMultihash(code, h.Sum(nil))
+}
+
+// DecodeMultihash parses multihash from the beginning of b and returns count of read bytes
+func DecodeMultihash(b []byte) (*Multihash, int, error) {
+	code, n, err := utils.DecodeUvarint(b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	length, m, err := utils.DecodeUvarint(b[n:])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	n += m
+
+	if uint64(len(b)-n) < length {
+		return nil, 0, ErrMultihashLength
+	}
+
+	digest := make([]byte, length)
+	copy(digest, b[n:])
+
+	return NewMultihash(code, digest), n + int(length), nil
+}
+
+// ParseMultihash parses whole b as multihash
+func ParseMultihash(b []byte) (*Multihash, error) {
+	if len(b) < 2 {
+		return nil, ErrMultihashTooShort
+	}
+
+	mh, n, err := DecodeMultihash(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != len(b) {
+		return nil, ErrMultihashLength
+	}
+
+	return mh, nil
+}
+
+func (ref *Multihash) Bytes() []byte {
+	buf := utils.EncodeUvarint(ref.Code)
+	buf = append(buf, utils.EncodeUvarint(uint64(len(ref.Digest)))...)
+
+	return append(buf, ref.Digest...)
+}
+
+// B58String returns base58 representation used by CID v0
+func (ref *Multihash) B58String() string {
+	return utils.Base58Encode(ref.Bytes())
+}
+
+// Verify checks digest against data hashed by newHash. Digest must have full size of hash
+func (ref *Multihash) Verify(data []byte, newHash func() hash.Hash) error {
+	return ref.verify(data, newHash, false)
+}
+
+// VerifyTruncated checks digest against data hashed by newHash. Digest can be prefix of hash, but not empty
+func (ref *Multihash) VerifyTruncated(data []byte, newHash func() hash.Hash) error {
+	return ref.verify(data, newHash, true)
+}
+
+func (ref *Multihash) verify(data []byte, newHash func() hash.Hash, truncated bool) error {
+	h := newHash()
+
+	switch {
+	case len(ref.Digest) == 0:
+		return ErrEmptyDigest
+	case len(ref.Digest) > h.Size():
+		return ErrDigestTooLong
+	case len(ref.Digest) < h.Size() && !truncated:
+		return ErrDigestTruncated
+	}
+
+	h.Write(data)
+
+	if !bytes.Equal(h.Sum(nil)[:len(ref.Digest)], ref.Digest) {
+		return ErrDigestMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import "errors"
+
+var (
+	ErrBigIntOverflow             = errors.New("big integer does not fit into requested number of bytes")
+	ErrBitIndexOutOfRange         = errors.New("bit index is out of range")
+	ErrBitSetSizeMismatch         = errors.New("bit sets have different sizes")
+	ErrHexOddLength               = errors.New("hex string has odd length")
+	ErrHexInvalidSize             = errors.New("hex string has invalid size")
+	ErrInvalidUint64DTO           = errors.New("uint64 DTO should be array of two uint32 values")
+	ErrAmountInvalidFormat        = errors.New("amount has invalid decimal format")
+	ErrAmountPrecision            = errors.New("amount has more fractional digits than divisibility")
+	ErrAmountOverflow             = errors.New("amount is out of uint64 range")
+	ErrAmountDivisibilityMismatch = errors.New("amounts have different divisibility")
+	ErrDivisionByZero             = errors.New("division by zero")
+	ErrVarintTruncated            = errors.New("varint is truncated")
+	ErrVarintOverflow             = errors.New("varint overflows 64-bit integer")
+	ErrVarintNotMinimal           = errors.New("varint is not minimally encoded")
+	ErrVarintNegative             = errors.New("unsigned varint can't encode negative value")
+	ErrBitCountTooLarge           = errors.New("bit count should not exceed 64")
+	ErrBitValueOverflow           = errors.New("value does not fit into requested number of bits")
+	ErrByteSizeInvalidFormat      = errors.New("byte size has invalid format")
+	ErrByteSizeOverflow           = errors.New("byte size is out of uint64 range")
+	ErrTimeBeforeEpoch            = errors.New("time is before network epoch")
+	ErrInvalidDeadline            = errors.New("deadline should be positive and should not exceed max deadline")
+	ErrNetworkTimeSize            = errors.New("network time should be 8 bytes")
+	ErrAddressInvalidSize         = errors.New("address is too short")
+	ErrAddressChecksum            = errors.New("address checksum is invalid")
+	ErrAddressNetwork             = errors.New("address belongs to another network")
+)
@@ -0,0 +1,7 @@
+package gen
+
+import "errors"
+
+var (
+	ErrNotPointer = errors.New("value should be a non-nil pointer")
+)
@@ -0,0 +1,217 @@
+package gen
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	// SeedEnv is environment variable which overrides seed of generators created by NewFromEnv and ForTest
+	SeedEnv = "XPX_GEN_SEED"
+
+	maxFillDepth = 5
+	maxFillLen   = 4
+	bigIntBytes  = 8
+)
+
+var bigIntPtrType = reflect.TypeOf(&big.Int{})
+
+// Generator produces reproducible random test data from seed
+type Generator struct {
+	rnd  *rand.Rand
+	seed int64
+}
+
+func New(seed int64) *Generator {
+	return &Generator{
+		rnd:  rand.New(rand.NewSource(seed)),
+		seed: seed,
+	}
+}
+
+// NewFromEnv uses seed from SeedEnv variable or current time
+func NewFromEnv() *Generator {
+	if s, ok := os.LookupEnv(SeedEnv); ok {
+		if seed, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return New(seed)
+		}
+	}
+
+	return New(time.Now().UnixNano())
+}
+
+// ForTest returns generator from NewFromEnv and logs its seed, so failed test can be replayed
+// by setting SeedEnv variable
+func ForTest(t testing.TB) *Generator {
+	t.Helper()
+
+	g := NewFromEnv()
+	t.Logf("gen: %s=%d", SeedEnv, g.seed)
+
+	return g
+}
+
+func (ref *Generator) Seed() int64 {
+	return ref.seed
+}
+
+// Rand returns underlying source for cases which are not covered by generator
+func (ref *Generator) Rand() *rand.Rand {
+	return ref.rnd
+}
+
+func (ref *Generator) Bytes(n int) []byte {
+	b := make([]byte, n)
+	ref.rnd.Read(b)
+
+	return b
+}
+
+func (ref *Generator) Uint64() uint64 {
+	return ref.rnd.Uint64()
+}
+
+// Hex returns hex string of n random bytes
+func (ref *Generator) Hex(n int) string {
+	return hex.EncodeToString(ref.Bytes(n))
+}
+
+// OddHex returns hex string with 2n-1 characters which doesn't start from zero,
+// so HexDecodeStringOdd result has n bytes
+func (ref *Generator) OddHex(n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	s := ref.Hex(n)[1:]
+	if s[0] == '0' {
+		s = string("123456789abcdef"[ref.rnd.Intn(15)]) + s[1:]
+	}
+
+	return s
+}
+
+// BigInt returns value in [min, max) range
+func (ref *Generator) BigInt(min, max *big.Int) *big.Int {
+	span := new(big.Int).Sub(max, min)
+	if span.Sign() <= 0 {
+		return new(big.Int).Set(min)
+	}
+
+	return span.Add(new(big.Int).Rand(ref.rnd, span), min)
+}
+
+// EdgeBigInts returns values which often break conversions: zero, one, values around max uint64
+// and values with the highest bit of byte set, whose signed big endian form has leading 0x00 byte
+func EdgeBigInts() []*big.Int {
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(maxUint64, big.NewInt(1)),
+		maxUint64,
+		new(big.Int).Add(maxUint64, big.NewInt(1)),
+	}
+
+	for bits := uint(7); bits < 64; bits += 8 {
+		values = append(values, new(big.Int).Lsh(big.NewInt(1), bits))
+	}
+
+	return values
+}
+
+// EdgeBytes returns byte arrays of size n which often break conversions:
+// all zeros, all 0xff, leading 0x00 and trailing 0x00
+func (ref *Generator) EdgeBytes(n int) [][]byte {
+	zeros := make([]byte, n)
+	ones := make([]byte, n)
+	leading := ref.Bytes(n)
+	trailing := ref.Bytes(n)
+
+	for i := range ones {
+		ones[i] = 0xff
+	}
+
+	if n > 0 {
+		leading[0] = 0x00
+		trailing[n-1] = 0x00
+	}
+
+	return [][]byte{zeros, ones, leading, trailing}
+}
+
+// Fill sets random values to exported fields of struct pointed by v.
+// Slices and maps get up to 4 elements, *big.Int gets up to 8 bytes value
+func (ref *Generator) Fill(v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrNotPointer
+	}
+
+	ref.fill(rv.Elem(), 0)
+
+	return nil
+}
+
+func (ref *Generator) fill(v reflect.Value, depth int) {
+	if depth > maxFillDepth || !v.CanSet() {
+		return
+	}
+
+	if v.Type() == bigIntPtrType {
+		v.Set(reflect.ValueOf(new(big.Int).SetBytes(ref.Bytes(ref.rnd.Intn(bigIntBytes) + 1))))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(ref.rnd.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(ref.rnd.Uint64()) >> uint(64-v.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(ref.rnd.Uint64() >> uint(64-v.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(ref.rnd.Float64())
+	case reflect.String:
+		v.SetString(ref.Hex(ref.rnd.Intn(maxFillLen*2) + 1))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			ref.fill(v.Index(i), depth+1)
+		}
+	case reflect.Slice:
+		n := ref.rnd.Intn(maxFillLen + 1)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+
+		for i := 0; i < n; i++ {
+			ref.fill(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		n := ref.rnd.Intn(maxFillLen + 1)
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			value := reflect.New(v.Type().Elem()).Elem()
+			ref.fill(key, depth+1)
+			ref.fill(value, depth+1)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.Ptr:
+		p := reflect.New(v.Type().Elem())
+		ref.fill(p.Elem(), depth+1)
+		v.Set(p)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			ref.fill(v.Field(i), depth+1)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package gen
+
+import (
+	"github.com/proximax-storage/go-xpx-utils"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+type testNested struct {
+	Name string
+	Tags []string
+}
+
+type testDTO struct {
+	Id      uint64
+	Amount  *big.Int
+	Data    []byte
+	Key     [4]byte
+	Nested  *testNested
+	Meta    map[string]int32
+	private int
+}
+
+func TestGenerator_Reproducible(t *testing.T) {
+	a, b := New(42), New(42)
+
+	assert.Equal(t, a.Bytes(16), b.Bytes(16))
+	assert.Equal(t, a.Hex(8), b.Hex(8))
+
+	dtoA, dtoB := &testDTO{}, &testDTO{}
+	assert.Nil(t, a.Fill(dtoA))
+	assert.Nil(t, b.Fill(dtoB))
+	assert.Equal(t, dtoA, dtoB)
+	assert.NotNil(t, dtoA.Nested)
+	assert.NotNil(t, dtoA.Amount)
+	assert.Equal(t, 0, dtoA.private)
+}
+
+func TestGenerator_Values(t *testing.T) {
+	g := ForTest(t)
+
+	odd := g.OddHex(4)
+	assert.Len(t, odd, 7)
+
+	b, err := utils.HexDecodeStringOdd(odd)
+	assert.Nil(t, err)
+	assert.Len(t, b, 4)
+
+	min, max := big.NewInt(10), big.NewInt(20)
+	for i := 0; i < 100; i++ {
+		v := g.BigInt(min, max)
+		assert.True(t, v.Cmp(min) >= 0 && v.Cmp(max) < 0)
+	}
+
+	for _, v := range EdgeBigInts() {
+		if v.BitLen() <= 64 {
+			assert.True(t, utils.EqualsBigInts(v, utils.BytesToBigInteger(utils.BigIntToByteArray(v, 8))))
+		}
+	}
+
+	for _, edge := range g.EdgeBytes(8) {
+		assert.Len(t, edge, 8)
+	}
+
+	assert.Equal(t, ErrNotPointer, g.Fill(testDTO{}))
+}
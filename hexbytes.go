@@ -0,0 +1,182 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// HexBytes is byte array which is represented by hex string in JSON, text and SQL.
+// Decoding follows HexDecodeStringOdd rules
+type HexBytes []byte
+
+func (ref HexBytes) String() string {
+	return hex.EncodeToString(ref)
+}
+
+func (ref HexBytes) Format(f fmt.State, verb rune) {
+	formatHex(f, verb, ref)
+}
+
+func (ref HexBytes) MarshalText() ([]byte, error) {
+	return []byte(ref.String()), nil
+}
+
+func (ref *HexBytes) UnmarshalText(text []byte) error {
+	b, err := HexDecodeStringOdd(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ref = b
+
+	return nil
+}
+
+func (ref HexBytes) MarshalJSON() ([]byte, error) {
+	if ref == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(ref.String())
+}
+
+func (ref *HexBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*ref = nil
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return ref.UnmarshalText([]byte(s))
+}
+
+// Scan accepts hex string stored as string or []byte
+func (ref *HexBytes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*ref = nil
+		return nil
+	case string:
+		return ref.UnmarshalText([]byte(v))
+	case []byte:
+		return ref.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("cannot scan %T into HexBytes", src)
+}
+
+// Value stores bytes as hex string
+func (ref HexBytes) Value() (driver.Value, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	return ref.String(), nil
+}
+
+// Hash32 is 32 bytes hash which is represented by hex string in JSON, text and SQL
+type Hash32 [32]byte
+
+func (ref Hash32) String() string {
+	return hex.EncodeToString(ref[:])
+}
+
+func (ref Hash32) Format(f fmt.State, verb rune) {
+	formatHex(f, verb, ref[:])
+}
+
+func (ref Hash32) MarshalText() ([]byte, error) {
+	return []byte(ref.String()), nil
+}
+
+func (ref *Hash32) UnmarshalText(text []byte) error {
+	return decodeFixedHex(ref[:], text)
+}
+
+func (ref Hash32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ref.String())
+}
+
+// UnmarshalJSON leaves hash unchanged for null like encoding/json does
+func (ref *Hash32) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return ref.UnmarshalText([]byte(s))
+}
+
+// Scan accepts hex string stored as string or []byte
+func (ref *Hash32) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return ref.UnmarshalText([]byte(v))
+	case []byte:
+		return ref.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("cannot scan %T into Hash32", src)
+}
+
+// Value stores hash as hex string
+func (ref Hash32) Value() (driver.Value, error) {
+	return ref.String(), nil
+}
+
+func decodeFixedHex(dst []byte, text []byte) error {
+	b, err := HexDecodeStringOdd(string(text))
+	if err != nil {
+		return err
+	}
+
+	if len(b) != len(dst) {
+		return ErrHexInvalidSize
+	}
+
+	copy(dst, b)
+
+	return nil
+}
+
+func formatHex(f fmt.State, verb rune, b []byte) {
+	var s string
+
+	switch verb {
+	case 's', 'v', 'x':
+		s = hex.EncodeToString(b)
+	case 'X':
+		s = strings.ToUpper(hex.EncodeToString(b))
+	case 'q':
+		s = `"` + hex.EncodeToString(b) + `"`
+	default:
+		s = fmt.Sprintf("%%!%c(hex=%s)", verb, hex.EncodeToString(b))
+	}
+
+	if w, ok := f.Width(); ok && len(s) < w {
+		pad := strings.Repeat(" ", w-len(s))
+
+		if f.Flag('-') {
+			s += pad
+		} else {
+			s = pad + s
+		}
+	}
+
+	_, _ = fmt.Fprint(f, s)
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type testHexDTO struct {
+	Data HexBytes `json:"data"`
+	Hash Hash32   `json:"hash"`
+}
+
+func TestHexBytes_JSON(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	dto := &testHexDTO{}
+
+	err := json.Unmarshal([]byte(`{"data":"abc","hash":"`+hash+`"}`), dto)
+	assert.Nil(t, err)
+	assert.Equal(t, HexBytes{0x0a, 0xbc}, dto.Data)
+	assert.Equal(t, hash, dto.Hash.String())
+
+	b, err := json.Marshal(dto)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"data":"0abc","hash":"`+hash+`"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"hash":"abcd"}`), dto)
+	assert.Equal(t, ErrHexInvalidSize, err)
+}
+
+func TestHexBytes_Format(t *testing.T) {
+	b := HexBytes{0xab, 0x01}
+
+	assert.Equal(t, "ab01 AB01 \"ab01\" ab01", fmt.Sprintf("%v %X %q %s", b, b, b, b))
+	assert.Equal(t, "  ab01", fmt.Sprintf("%6s", b))
+}
+
+func TestHexBytes_SQL(t *testing.T) {
+	b := HexBytes{}
+
+	assert.Nil(t, b.Scan([]byte("0102")))
+	assert.Equal(t, HexBytes{0x01, 0x02}, b)
+
+	v, err := b.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "0102", v)
+
+	assert.Nil(t, b.Scan(nil))
+	assert.Nil(t, b)
+}
+
+func TestHash32_JSONNull(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	dto := &testHexDTO{}
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"data":null,"hash":null}`), dto))
+	assert.Equal(t, Hash32{}, dto.Hash)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"hash":"`+hash+`"}`), dto))
+	assert.Nil(t, json.Unmarshal([]byte(`{"hash":null}`), dto))
+	assert.Equal(t, hash, dto.Hash.String())
+}
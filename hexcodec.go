@@ -0,0 +1,298 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// InvalidHexCharError describes a character which is not accepted by HexCodec
+type InvalidHexCharError struct {
+	Offset int
+	Char   byte
+}
+
+func (ref *InvalidHexCharError) Error() string {
+	return fmt.Sprintf("invalid hex character %q at offset %d", ref.Char, ref.Offset)
+}
+
+type HexOption func(codec *HexCodec)
+
+// WithHexPrefix accepts optional 0x or 0X prefix while decoding
+func WithHexPrefix() HexOption {
+	return func(codec *HexCodec) {
+		codec.acceptPrefix = true
+	}
+}
+
+// WithHexSeparators skips whitespaces and colons while decoding
+func WithHexSeparators() HexOption {
+	return func(codec *HexCodec) {
+		codec.acceptSeparators = true
+	}
+}
+
+// WithHexOddLength pads odd length input with leading zero like HexDecodeStringOdd
+func WithHexOddLength() HexOption {
+	return func(codec *HexCodec) {
+		codec.acceptOddLength = true
+	}
+}
+
+// WithHexUpperCase encodes with upper case letters
+func WithHexUpperCase() HexOption {
+	return func(codec *HexCodec) {
+		codec.upperCase = true
+	}
+}
+
+// WithHexOutputPrefix writes 0x prefix while encoding
+func WithHexOutputPrefix() HexOption {
+	return func(codec *HexCodec) {
+		codec.outputPrefix = true
+	}
+}
+
+// HexCodec is configurable hex encoder and decoder.
+// Decoding is always case insensitive
+type HexCodec struct {
+	acceptPrefix     bool
+	acceptSeparators bool
+	acceptOddLength  bool
+	upperCase        bool
+	outputPrefix     bool
+}
+
+// TolerantHexCodec accepts prefixes, separators and odd length input
+var TolerantHexCodec = NewHexCodec(WithHexPrefix(), WithHexSeparators(), WithHexOddLength())
+
+func NewHexCodec(options ...HexOption) *HexCodec {
+	codec := &HexCodec{}
+
+	for _, option := range options {
+		option(codec)
+	}
+
+	return codec
+}
+
+func isHexSeparator(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', ':':
+		return true
+	}
+
+	return false
+}
+
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+
+	return 0, false
+}
+
+func hasHexPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// DecodeString returns the bytes represented by the hex string s
+func (ref *HexCodec) DecodeString(s string) ([]byte, error) {
+	start := 0
+
+	if ref.acceptPrefix && hasHexPrefix(s) {
+		start = 2
+	}
+
+	digits := 0
+
+	for i := start; i < len(s); i++ {
+		c := s[i]
+
+		if ref.acceptSeparators && isHexSeparator(c) {
+			continue
+		}
+
+		if _, ok := fromHexChar(c); !ok {
+			return nil, &InvalidHexCharError{Offset: i, Char: c}
+		}
+
+		digits++
+	}
+
+	if digits%2 != 0 && !ref.acceptOddLength {
+		return nil, ErrHexOddLength
+	}
+
+	// digits are decoded directly into output, so no intermediate copy of decoded data is left in memory.
+	// Odd count of digits is padded by leading zero nibble
+	out := make([]byte, (digits+1)/2)
+	pos := digits % 2
+
+	for i := start; i < len(s); i++ {
+		v, ok := fromHexChar(s[i])
+		if !ok {
+			continue
+		}
+
+		if pos%2 == 0 {
+			out[pos/2] = v << 4
+		} else {
+			out[pos/2] |= v
+		}
+
+		pos++
+	}
+
+	return out, nil
+}
+
+// Decode returns the bytes represented by the hex src
+func (ref *HexCodec) Decode(src []byte) ([]byte, error) {
+	return ref.DecodeString(string(src))
+}
+
+// EncodeToString returns hex representation of b
+func (ref *HexCodec) EncodeToString(b []byte) string {
+	s := hex.EncodeToString(b)
+
+	if ref.upperCase {
+		s = strings.ToUpper(s)
+	}
+
+	if ref.outputPrefix {
+		s = "0x" + s
+	}
+
+	return s
+}
+
+// NewDecoder returns reader which decodes hex characters from r.
+// Stream can't be padded from the left, so odd length input always returns ErrHexOddLength
+func (ref *HexCodec) NewDecoder(r io.Reader) io.Reader {
+	return &hexDecoder{codec: ref, r: bufio.NewReader(r)}
+}
+
+// NewEncoder returns writer which writes hex characters to w
+func (ref *HexCodec) NewEncoder(w io.Writer) io.Writer {
+	return &hexEncoder{codec: ref, w: w}
+}
+
+type hexDecoder struct {
+	codec   *HexCodec
+	r       *bufio.Reader
+	offset  int
+	started bool
+	err     error
+}
+
+func (ref *hexDecoder) nextNibble() (byte, error) {
+	for {
+		c, err := ref.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		ref.offset++
+
+		if ref.codec.acceptSeparators && isHexSeparator(c) {
+			continue
+		}
+
+		v, ok := fromHexChar(c)
+		if !ok {
+			return 0, &InvalidHexCharError{Offset: ref.offset - 1, Char: c}
+		}
+
+		return v, nil
+	}
+}
+
+func (ref *hexDecoder) Read(p []byte) (int, error) {
+	if ref.err != nil {
+		return 0, ref.err
+	}
+
+	if !ref.started {
+		ref.started = true
+
+		if ref.codec.acceptPrefix {
+			if b, _ := ref.r.Peek(2); hasHexPrefix(string(b)) {
+				_, _ = ref.r.Discard(2)
+				ref.offset += 2
+			}
+		}
+	}
+
+	n := 0
+	for n < len(p) {
+		if n > 0 && ref.r.Buffered() == 0 {
+			break
+		}
+
+		hi, err := ref.nextNibble()
+		if err != nil {
+			ref.err = err
+			break
+		}
+
+		lo, err := ref.nextNibble()
+		if err == io.EOF {
+			err = ErrHexOddLength
+		}
+		if err != nil {
+			ref.err = err
+			break
+		}
+
+		p[n] = hi<<4 | lo
+		n++
+	}
+
+	if n > 0 && ref.err == io.EOF {
+		return n, nil
+	}
+
+	return n, ref.err
+}
+
+type hexEncoder struct {
+	codec   *HexCodec
+	w       io.Writer
+	started bool
+}
+
+func (ref *hexEncoder) Write(p []byte) (int, error) {
+	if !ref.started {
+		ref.started = true
+
+		if ref.codec.outputPrefix {
+			if _, err := io.WriteString(ref.w, "0x"); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	s := hex.EncodeToString(p)
+	if ref.codec.upperCase {
+		s = strings.ToUpper(s)
+	}
+
+	if _, err := io.WriteString(ref.w, s); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
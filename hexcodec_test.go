@@ -0,0 +1,60 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestHexCodec_DecodeString(t *testing.T) {
+	b, err := TolerantHexCodec.DecodeString("0xAb:cD 1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x0a, 0xbc, 0xd1}, b)
+
+	b, err = TolerantHexCodec.DecodeString("abc")
+	assert.Nil(t, err)
+	want, _ := HexDecodeStringOdd("abc")
+	assert.Equal(t, want, b)
+
+	_, err = NewHexCodec().DecodeString("abc")
+	assert.Equal(t, ErrHexOddLength, err)
+
+	_, err = NewHexCodec().DecodeString("0xab")
+	assert.Equal(t, &InvalidHexCharError{Offset: 1, Char: 'x'}, err)
+
+	_, err = TolerantHexCodec.DecodeString("0x ab zz")
+	assert.Equal(t, &InvalidHexCharError{Offset: 6, Char: 'z'}, err)
+}
+
+func TestHexCodec_EncodeToString(t *testing.T) {
+	codec := NewHexCodec(WithHexUpperCase(), WithHexOutputPrefix())
+
+	assert.Equal(t, "0xABCD", codec.EncodeToString([]byte{0xab, 0xcd}))
+	assert.Equal(t, "abcd", NewHexCodec().EncodeToString([]byte{0xab, 0xcd}))
+}
+
+func TestHexCodec_Stream(t *testing.T) {
+	b, err := ioutil.ReadAll(TolerantHexCodec.NewDecoder(strings.NewReader("0x01 02\n0A:ff")))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01, 0x02, 0x0a, 0xff}, b)
+
+	_, err = ioutil.ReadAll(TolerantHexCodec.NewDecoder(strings.NewReader("012")))
+	assert.Equal(t, ErrHexOddLength, err)
+
+	_, err = ioutil.ReadAll(TolerantHexCodec.NewDecoder(strings.NewReader("01g2")))
+	assert.Equal(t, &InvalidHexCharError{Offset: 2, Char: 'g'}, err)
+
+	buf := &bytes.Buffer{}
+	w := NewHexCodec(WithHexOutputPrefix()).NewEncoder(buf)
+	_, err = w.Write([]byte{0x01})
+	assert.Nil(t, err)
+	_, err = w.Write([]byte{0xab})
+	assert.Nil(t, err)
+	assert.Equal(t, "0x01ab", buf.String())
+}
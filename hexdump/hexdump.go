@@ -0,0 +1,192 @@
+package hexdump
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	annotateBytesPerLine = 16
+	diffBytesPerLine     = 8
+	unknownFieldName     = "<unknown>"
+)
+
+// Field describes named range of bytes in serialized buffer
+type Field struct {
+	Name   string
+	Offset int
+	Size   int
+}
+
+func NewField(name string, offset, size int) *Field {
+	return &Field{
+		Name:   name,
+		Offset: offset,
+		Size:   size,
+	}
+}
+
+// valid checks that field describes non-negative range which doesn't overflow int
+func (ref *Field) valid() bool {
+	return ref.Offset >= 0 && ref.Size >= 0 && ref.Offset+ref.Size >= ref.Offset
+}
+
+// Dump returns offset, hex and ASCII columns like `hexdump -C`
+func Dump(b []byte) string {
+	return hex.Dump(b)
+}
+
+// Annotate prints every field with its offset and bytes. Bytes not covered by fields
+// are printed as unknown ranges, fields which exceed buffer are marked as truncated,
+// fields with negative offset or size are marked as invalid
+func Annotate(b []byte, fields ...*Field) string {
+	sorted := make([]*Field, len(fields))
+	copy(sorted, fields)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	nameWidth := len(unknownFieldName)
+	for _, field := range sorted {
+		if len(field.Name) > nameWidth {
+			nameWidth = len(field.Name)
+		}
+	}
+
+	sb := &strings.Builder{}
+	offset := 0
+
+	for _, field := range sorted {
+		if !field.valid() {
+			writeInvalidField(sb, field, nameWidth)
+			continue
+		}
+
+		if field.Offset > offset {
+			writeAnnotatedRange(sb, b, NewField(unknownFieldName, offset, field.Offset-offset), nameWidth)
+		}
+
+		writeAnnotatedRange(sb, b, field, nameWidth)
+
+		if end := field.Offset + field.Size; end > offset {
+			offset = end
+		}
+	}
+
+	if offset < len(b) {
+		writeAnnotatedRange(sb, b, NewField(unknownFieldName, offset, len(b)-offset), nameWidth)
+	}
+
+	return sb.String()
+}
+
+func writeAnnotatedRange(sb *strings.Builder, b []byte, field *Field, nameWidth int) {
+	start, end := field.Offset, field.Offset+field.Size
+
+	if start > len(b) {
+		start = len(b)
+	}
+
+	if end < start {
+		end = start
+	}
+
+	if end > len(b) {
+		end = len(b)
+	}
+
+	data := b[start:end]
+	indent := strings.Repeat(" ", 10+nameWidth+2)
+
+	fmt.Fprintf(sb, "%08x  %-*s  ", field.Offset, nameWidth, field.Name)
+
+	if len(data) == 0 {
+		sb.WriteString("(empty)")
+	}
+
+	for i := 0; i < len(data); i += annotateBytesPerLine {
+		if i > 0 {
+			sb.WriteString("\n" + indent)
+		}
+
+		lineEnd := i + annotateBytesPerLine
+		if lineEnd > len(data) {
+			lineEnd = len(data)
+		}
+
+		sb.WriteString(hexBytes(data[i:lineEnd]))
+	}
+
+	if end-start < field.Size {
+		fmt.Fprintf(sb, " (truncated, %d of %d bytes)", end-start, field.Size)
+	}
+
+	sb.WriteString("\n")
+}
+
+func writeInvalidField(sb *strings.Builder, field *Field, nameWidth int) {
+	fmt.Fprintf(sb, "????????  %-*s  (invalid, offset %d, size %d)\n", nameWidth, field.Name, field.Offset, field.Size)
+}
+
+// Diff prints two buffers side by side. Rows with differences are followed by a marker line
+// which points to differing bytes, missing bytes are printed as --
+func Diff(a, b []byte) string {
+	size := len(a)
+	if len(b) > size {
+		size = len(b)
+	}
+
+	sb := &strings.Builder{}
+
+	for offset := 0; offset < size; offset += diffBytesPerLine {
+		left := make([]string, diffBytesPerLine)
+		right := make([]string, diffBytesPerLine)
+		markers := make([]string, diffBytesPerLine)
+		differs := false
+
+		for i := range left {
+			idx := offset + i
+			left[i], right[i], markers[i] = diffCell(a, idx), diffCell(b, idx), "  "
+
+			if idx < size && left[i] != right[i] {
+				markers[i] = "^^"
+				differs = true
+			}
+		}
+
+		marker := " "
+		if differs {
+			marker = "!"
+		}
+
+		fmt.Fprintf(sb, "%s %08x  %s  |  %s\n", marker, offset, strings.Join(left, " "), strings.Join(right, " "))
+
+		if differs {
+			m := strings.Join(markers, " ")
+			fmt.Fprintf(sb, "%s  %s  |  %s\n", strings.Repeat(" ", 10), m, m)
+		}
+	}
+
+	return sb.String()
+}
+
+func diffCell(b []byte, idx int) string {
+	if idx < len(b) {
+		return hex.EncodeToString(b[idx : idx+1])
+	}
+
+	return "--"
+}
+
+func hexBytes(b []byte) string {
+	parts := make([]string, len(b))
+
+	for i := range b {
+		parts[i] = hex.EncodeToString(b[i : i+1])
+	}
+
+	return strings.Join(parts, " ")
+}
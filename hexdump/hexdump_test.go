@@ -0,0 +1,42 @@
+package hexdump
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	assert.Equal(t, "00000000  41 42 00                                          |AB.|\n", Dump([]byte{0x41, 0x42, 0x00}))
+}
+
+func TestAnnotate(t *testing.T) {
+	b := []byte{0x05, 0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb}
+
+	want := "00000000  size       05 00 00 00\n" +
+		"00000004  <unknown>  01\n" +
+		"00000005  signer     aa bb (truncated, 2 of 4 bytes)\n"
+
+	assert.Equal(t, want, Annotate(b, NewField("signer", 5, 4), NewField("size", 0, 4)))
+}
+
+func TestDiff(t *testing.T) {
+	a := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09}
+	b := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x0a, 0x0b}
+
+	want := "  00000000  01 02 03 04 05 06 07 08  |  01 02 03 04 05 06 07 08\n" +
+		"! 00000008  09 -- -- -- -- -- -- --  |  0a 0b -- -- -- -- -- --\n" +
+		"            ^^ ^^                    |  ^^ ^^                  \n"
+
+	assert.Equal(t, want, Diff(a, b))
+}
+
+func TestAnnotate_InvalidFields(t *testing.T) {
+	b := []byte{0x01, 0x02, 0x03}
+
+	want := "????????  c          (invalid, offset -1, size 2)\n" +
+		"00000000  a          01 02\n" +
+		"????????  b          (invalid, offset 2, size -1)\n" +
+		"00000002  <unknown>  03\n"
+
+	assert.Equal(t, want, Annotate(b, NewField("a", 0, 2), NewField("b", 2, -1), NewField("c", -1, 2)))
+}
@@ -58,3 +58,18 @@ func (ref *interceptedCore) Write(entry zapcore.Entry, fields []zapcore.Field) e
 
 	return nil
 }
+
+func (ref *interceptedCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := ref.encoder.Clone()
+
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	return &interceptedCore{
+		Core:                 ref.Core.With(fields),
+		encoder:              enc,
+		fieldsInterceptor:    ref.fieldsInterceptor,
+		logStringInterceptor: ref.logStringInterceptor,
+	}
+}
@@ -77,6 +77,21 @@ func (ref *Logger) addContextField(ctx context.Context, fields []zap.Field) []za
 	return fields
 }
 
+// With returns child logger with fields added to every log, fields are redacted like in log methods
+func (ref *Logger) With(fields ...zap.Field) *Logger {
+	fields = redactFields(fields)
+
+	child := *ref
+	child.Logger = ref.Logger.With(fields...)
+	child.cores = make([]zapcore.Core, len(ref.cores))
+
+	for idx, core := range ref.cores {
+		child.cores[idx] = core.With(fields)
+	}
+
+	return &child
+}
+
 func (ref *Logger) log(lvl zapcore.Level, msg string, fields ...zap.Field) {
 	if ce := ref.Check(lvl, msg); ce != nil {
 		if len(ref.cores) != 0 {
@@ -85,7 +100,7 @@ func (ref *Logger) log(lvl zapcore.Level, msg string, fields ...zap.Field) {
 			}
 		}
 
-		ce.Write(fields...)
+		ce.Write(redactFields(fields)...)
 	}
 }
 
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"reflect"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactFields replaces values of fields registered as sensitive in str package,
+// so the same policy is applied to String() output and to structured logs.
+// Reflected structs are logged by str.Marshaler, so their `str` tags and sensitive fields are honored too
+func redactFields(fields []zap.Field) []zap.Field {
+	var redacted []zap.Field
+
+	for idx, f := range fields {
+		var replacement zap.Field
+
+		switch {
+		case str.IsSensitiveField(f.Key):
+			replacement = zap.String(f.Key, str.Redact(zapFieldValue(f)))
+		case f.Type == zapcore.ReflectType && isStruct(f.Interface):
+			replacement = zap.Object(f.Key, str.Marshaler(f.Interface))
+		default:
+			continue
+		}
+
+		if redacted == nil {
+			redacted = make([]zap.Field, len(fields))
+			copy(redacted, fields)
+		}
+
+		redacted[idx] = replacement
+	}
+
+	if redacted == nil {
+		return fields
+	}
+
+	return redacted
+}
+
+func zapFieldValue(f zap.Field) interface{} {
+	switch {
+	case f.Interface != nil:
+		return f.Interface
+	case f.Type == zapcore.StringType:
+		return f.String
+	}
+
+	return f.Integer
+}
+
+func isStruct(value interface{}) bool {
+	v := reflect.ValueOf(value)
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+
+		v = v.Elem()
+	}
+
+	return v.Kind() == reflect.Struct
+}
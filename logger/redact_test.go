@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+)
+
+func TestLogger_RedactsSensitiveFields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := &Logger{Logger: zap.New(core)}
+
+	l.Info("login", zap.String("user", "alice"), zap.String("password", "qwerty"), zap.Int64("token", 12345))
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]interface{}{
+		"user":     "alice",
+		"password": "***",
+		"token":    "***",
+	}, entries[0].ContextMap())
+}
+
+type testAccount struct {
+	Address    string `str:"address"`
+	PrivateKey string
+	Seed       string `str:"seed,secret"`
+}
+
+func TestLogger_RedactsReflectedStructs(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := &Logger{Logger: zap.New(core)}
+
+	l.Info("login", zap.Any("acct", &testAccount{Address: "SAONSO", PrivateKey: "deadbeef", Seed: "words"}), zap.Any("n", 1))
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]interface{}{
+		"acct": map[string]interface{}{
+			"address":    "SAONSO",
+			"PrivateKey": "***",
+			"seed":       "***",
+		},
+		"n": int64(1),
+	}, entries[0].ContextMap())
+}
+
+func TestLogger_WithRedactsFields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := &Logger{Logger: zap.New(core)}
+
+	l.With(zap.String("password", "qwerty"), zap.String("user", "alice")).Info("login")
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]interface{}{
+		"user":     "alice",
+		"password": "***",
+	}, entries[0].ContextMap())
+}
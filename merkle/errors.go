@@ -0,0 +1,10 @@
+package merkle
+
+import "errors"
+
+var (
+	ErrNoLeaves         = errors.New("merkle tree has no leaves")
+	ErrLeafOutOfRange   = errors.New("leaf index is out of range")
+	ErrInvalidPosition  = errors.New("proof node position is invalid")
+	ErrHashSizeMismatch = errors.New("leaf hash size does not match hash function size")
+)
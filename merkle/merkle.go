@@ -0,0 +1,167 @@
+package merkle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"hash"
+
+	"github.com/proximax-storage/go-xpx-utils"
+)
+
+// Position is the side of sibling hash in the proof
+type Position string
+
+const (
+	Left  Position = "left"
+	Right Position = "right"
+)
+
+// ProofNode is sibling hash which is combined with current hash on the way to root
+type ProofNode struct {
+	Hash     utils.HexBytes `json:"hash"`
+	Position Position       `json:"position"`
+}
+
+// Root calculates merkle root of leaf hashes. Odd hash on each level is paired with itself
+// as catapult does. Root of empty tree is zero hash of newHash size
+func Root(leaves [][]byte, newHash func() hash.Hash) []byte {
+	if len(leaves) == 0 {
+		return make([]byte, newHash().Size())
+	}
+
+	level := leaves
+
+	for len(level) > 1 {
+		level = nextLevel(level, newHash)
+	}
+
+	return level[0]
+}
+
+// Proof returns path of sibling hashes from leaf with index to root
+func Proof(leaves [][]byte, index int, newHash func() hash.Hash) ([]*ProofNode, error) {
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+
+	if index < 0 || index >= len(leaves) {
+		return nil, ErrLeafOutOfRange
+	}
+
+	proof := make([]*ProofNode, 0)
+	level := leaves
+
+	for len(level) > 1 {
+		if index%2 == 0 {
+			sibling := index + 1
+			if sibling == len(level) {
+				sibling = index
+			}
+
+			proof = append(proof, &ProofNode{Hash: level[sibling], Position: Right})
+		} else {
+			proof = append(proof, &ProofNode{Hash: level[index-1], Position: Left})
+		}
+
+		level = nextLevel(level, newHash)
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks that leaf with proof leads to root
+func VerifyProof(leaf []byte, proof []*ProofNode, root []byte, newHash func() hash.Hash) (bool, error) {
+	current := leaf
+
+	for _, node := range proof {
+		switch node.Position {
+		case Left:
+			current = hashPair(node.Hash, current, newHash)
+		case Right:
+			current = hashPair(current, node.Hash, newHash)
+		default:
+			return false, ErrInvalidPosition
+		}
+	}
+
+	return bytes.Equal(current, root), nil
+}
+
+// RootHex calculates merkle root of hex encoded leaf hashes
+func RootHex(leaves []string, newHash func() hash.Hash) (string, error) {
+	decoded, err := decodeLeaves(leaves, newHash)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(Root(decoded, newHash)), nil
+}
+
+// ProofHex returns proof for hex encoded leaf hashes
+func ProofHex(leaves []string, index int, newHash func() hash.Hash) ([]*ProofNode, error) {
+	decoded, err := decodeLeaves(leaves, newHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return Proof(decoded, index, newHash)
+}
+
+// VerifyProofHex checks that hex encoded leaf with proof leads to hex encoded root
+func VerifyProofHex(leaf string, proof []*ProofNode, root string, newHash func() hash.Hash) (bool, error) {
+	leafBytes, err := utils.HexDecodeStringOdd(leaf)
+	if err != nil {
+		return false, err
+	}
+
+	rootBytes, err := utils.HexDecodeStringOdd(root)
+	if err != nil {
+		return false, err
+	}
+
+	return VerifyProof(leafBytes, proof, rootBytes, newHash)
+}
+
+func decodeLeaves(leaves []string, newHash func() hash.Hash) ([][]byte, error) {
+	size := newHash().Size()
+	decoded := make([][]byte, len(leaves))
+
+	for i, leaf := range leaves {
+		b, err := utils.HexDecodeStringOdd(leaf)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) != size {
+			return nil, ErrHashSizeMismatch
+		}
+
+		decoded[i] = b
+	}
+
+	return decoded, nil
+}
+
+func nextLevel(level [][]byte, newHash func() hash.Hash) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+
+	for i := 0; i < len(level); i += 2 {
+		right := level[i]
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+
+		next = append(next, hashPair(level[i], right, newHash))
+	}
+
+	return next
+}
+
+func hashPair(left, right []byte, newHash func() hash.Hash) []byte {
+	h := newHash()
+	h.Write(left)
+	h.Write(right)
+
+	return h.Sum(nil)
+}
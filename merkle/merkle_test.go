@@ -0,0 +1,77 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testLeaves(count int) [][]byte {
+	leaves := make([][]byte, count)
+
+	for i := range leaves {
+		sum := sha256.Sum256([]byte{byte(i)})
+		leaves[i] = sum[:]
+	}
+
+	return leaves
+}
+
+func TestRoot(t *testing.T) {
+	assert.Equal(t, make([]byte, 32), Root(nil, sha256.New))
+
+	leaves := testLeaves(3)
+	assert.Equal(t, leaves[0], Root(leaves[:1], sha256.New))
+
+	left := hashPair(leaves[0], leaves[1], sha256.New)
+	right := hashPair(leaves[2], leaves[2], sha256.New)
+	assert.Equal(t, hashPair(left, right, sha256.New), Root(leaves, sha256.New))
+}
+
+func TestProof(t *testing.T) {
+	leaves := testLeaves(5)
+	root := Root(leaves, sha256.New)
+
+	for i, leaf := range leaves {
+		proof, err := Proof(leaves, i, sha256.New)
+		assert.Nil(t, err)
+
+		ok, err := VerifyProof(leaf, proof, root, sha256.New)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+
+	proof, err := Proof(leaves, 1, sha256.New)
+	assert.Nil(t, err)
+
+	ok, err := VerifyProof(leaves[2], proof, root, sha256.New)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = Proof(leaves, 5, sha256.New)
+	assert.Equal(t, ErrLeafOutOfRange, err)
+}
+
+func TestRootHex(t *testing.T) {
+	leaves := testLeaves(4)
+	hexLeaves := make([]string, len(leaves))
+
+	for i, leaf := range leaves {
+		hexLeaves[i] = hex.EncodeToString(leaf)
+	}
+
+	root, err := RootHex(hexLeaves, sha256.New)
+	assert.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(Root(leaves, sha256.New)), root)
+
+	proof, err := ProofHex(hexLeaves, 3, sha256.New)
+	assert.Nil(t, err)
+
+	ok, err := VerifyProofHex(hexLeaves[3], proof, root, sha256.New)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	_, err = RootHex([]string{"abcd"}, sha256.New)
+	assert.Equal(t, ErrHashSizeMismatch, err)
+}
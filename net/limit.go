@@ -0,0 +1,46 @@
+package net
+
+import (
+	"errors"
+	"io"
+	"math"
+	"net/http"
+
+	"github.com/proximax-storage/go-xpx-utils"
+)
+
+var ErrUploadLimitExceeded = errors.New("request body exceeds upload limit")
+
+// NewUploadLimit fails request when its body is larger than limit
+func NewUploadLimit(limit utils.ByteSize) RequestOption {
+	return func(req *http.Request) {
+		if req.Body == nil {
+			return
+		}
+
+		req.Body = &limitedBody{ReadCloser: req.Body, left: uint64(limit)}
+	}
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	left uint64
+}
+
+func (ref *limitedBody) Read(p []byte) (int, error) {
+	// one byte over limit is read to detect exceeding, limit of max uint64 can't be exceeded anyway
+	if ref.left != math.MaxUint64 && uint64(len(p)) > ref.left+1 {
+		p = p[:ref.left+1]
+	}
+
+	n, err := ref.ReadCloser.Read(p)
+
+	if uint64(n) > ref.left {
+		ref.left = 0
+		return 0, ErrUploadLimitExceeded
+	}
+
+	ref.left -= uint64(n)
+
+	return n, err
+}
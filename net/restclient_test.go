@@ -2,10 +2,14 @@ package net
 
 import (
 	"context"
+	"github.com/proximax-storage/go-xpx-utils"
 	"github.com/proximax-storage/go-xpx-utils/mock"
 	"github.com/proximax-storage/go-xpx-utils/tests"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -143,3 +147,37 @@ func TestRestClient_Delete(t *testing.T) {
 
 	tests.ValidateStringers(t, test1Obj, inputDTO)
 }
+
+func TestRestClient_PostWithUploadLimit(t *testing.T) {
+	mockServer := mock.NewMockWithRoute(&mock.Router{
+		Path:                "/testPost",
+		RespHttpCode:        http.StatusOK,
+		RespBody:            testRespBody1,
+		ReqJsonBodyStruct:   testOne{},
+		AcceptedHttpMethods: []string{http.MethodPost},
+	})
+	defer mockServer.Close()
+
+	cl, err := NewRestClient(mockServer.GetServerURL())
+	assert.Nil(t, err)
+
+	outputDTO := &testOne{Msg: "Hello"}
+
+	_, err = cl.Post(testContext, "/testPost", outputDTO, &testOne{}, NewUploadLimit(utils.KiB))
+	assert.Nil(t, err)
+
+	_, err = cl.Post(testContext, "/testPost", outputDTO, &testOne{}, NewUploadLimit(4*utils.Byte))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), ErrUploadLimitExceeded.Error())
+}
+
+func TestNewUploadLimit_MaxUint64(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/testPost", strings.NewReader(testRespBody1))
+	assert.Nil(t, err)
+
+	NewUploadLimit(utils.ByteSize(math.MaxUint64))(req)
+
+	body, err := ioutil.ReadAll(req.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, testRespBody1, string(body))
+}
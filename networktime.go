@@ -0,0 +1,108 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+const (
+	// DefaultMaxDeadline is the maximum transaction deadline accepted by catapult nodes
+	DefaultMaxDeadline = 24 * time.Hour
+	networkTimeSize    = 8
+)
+
+// DefaultEpoch is nemesis block time of public networks, 2016-04-01 00:00:00 UTC
+var DefaultEpoch = NewEpoch(time.Date(2016, time.April, 1, 0, 0, 0, 0, time.UTC))
+
+// Epoch is network specific start of NetworkTime
+type Epoch struct {
+	start time.Time
+}
+
+func NewEpoch(start time.Time) *Epoch {
+	return &Epoch{start: start}
+}
+
+func (ref *Epoch) Start() time.Time {
+	return ref.start
+}
+
+// FromTime returns ErrTimeBeforeEpoch when t is earlier than epoch start
+func (ref *Epoch) FromTime(t time.Time) (NetworkTime, error) {
+	if t.Before(ref.start) {
+		return 0, ErrTimeBeforeEpoch
+	}
+
+	return NetworkTime(t.Sub(ref.start) / time.Millisecond), nil
+}
+
+func (ref *Epoch) ToTime(nt NetworkTime) time.Time {
+	return ref.start.Add(time.Duration(nt) * time.Millisecond)
+}
+
+func (ref *Epoch) Now() NetworkTime {
+	nt, _ := ref.FromTime(time.Now())
+
+	return nt
+}
+
+// Deadline returns now plus d. d must be positive and must not exceed max
+func (ref *Epoch) Deadline(d, max time.Duration) (NetworkTime, error) {
+	return ref.DeadlineFrom(time.Now(), d, max)
+}
+
+// DeadlineFrom returns now plus d. d must be positive and must not exceed max
+func (ref *Epoch) DeadlineFrom(now time.Time, d, max time.Duration) (NetworkTime, error) {
+	if d <= 0 || d > max {
+		return 0, ErrInvalidDeadline
+	}
+
+	return ref.FromTime(now.Add(d))
+}
+
+// NetworkTime is count of milliseconds since network Epoch
+type NetworkTime uint64
+
+func NetworkTimeFromBytes(b []byte) (NetworkTime, error) {
+	if len(b) != networkTimeSize {
+		return 0, ErrNetworkTimeSize
+	}
+
+	return NetworkTime(BytesToBigInteger(b).Uint64()), nil
+}
+
+// Bytes returns 8 bytes little endian representation
+func (ref NetworkTime) Bytes() []byte {
+	return BigIntToByteArray(new(big.Int).SetUint64(uint64(ref)), networkTimeSize)
+}
+
+func (ref NetworkTime) ToDTO() Uint64DTO {
+	return NewUint64DTO(uint64(ref))
+}
+
+// MarshalJSON encodes time as catapult [lo, hi] array
+func (ref NetworkTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ref.ToDTO())
+}
+
+// UnmarshalJSON leaves time unchanged for null like encoding/json does
+func (ref *NetworkTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	dto := Uint64DTO{}
+
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	*ref = NetworkTime(dto.ToUint64())
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEpoch(t *testing.T) {
+	now := DefaultEpoch.Start().Add(1500 * time.Millisecond)
+
+	nt, err := DefaultEpoch.FromTime(now)
+	assert.Nil(t, err)
+	assert.Equal(t, NetworkTime(1500), nt)
+	assert.Equal(t, now, DefaultEpoch.ToTime(nt))
+
+	_, err = DefaultEpoch.FromTime(DefaultEpoch.Start().Add(-time.Second))
+	assert.Equal(t, ErrTimeBeforeEpoch, err)
+
+	deadline, err := DefaultEpoch.DeadlineFrom(now, 2*time.Hour, DefaultMaxDeadline)
+	assert.Nil(t, err)
+	assert.Equal(t, NetworkTime(1500+2*60*60*1000), deadline)
+
+	_, err = DefaultEpoch.DeadlineFrom(now, 25*time.Hour, DefaultMaxDeadline)
+	assert.Equal(t, ErrInvalidDeadline, err)
+}
+
+func TestNetworkTime_Encoding(t *testing.T) {
+	nt := NetworkTime(0x100000002)
+
+	assert.Equal(t, []byte{0x02, 0, 0, 0, 0x01, 0, 0, 0}, nt.Bytes())
+
+	fromBytes, err := NetworkTimeFromBytes(nt.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, nt, fromBytes)
+
+	b, err := json.Marshal(nt)
+	assert.Nil(t, err)
+	assert.Equal(t, "[2,1]", string(b))
+
+	var got NetworkTime
+	assert.Nil(t, json.Unmarshal(b, &got))
+	assert.Equal(t, nt, got)
+}
+
+func TestNetworkTime_JSONNull(t *testing.T) {
+	nt := NetworkTime(5)
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), &nt))
+	assert.Equal(t, NetworkTime(5), nt)
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"runtime"
+)
+
+const redactedSecret = "[REDACTED]"
+
+var secretHexCodec = NewHexCodec(WithHexPrefix(), WithHexSeparators())
+
+// InvalidSecretHexError reports position of invalid character without revealing the character
+type InvalidSecretHexError struct {
+	Offset int
+}
+
+func (ref *InvalidSecretHexError) Error() string {
+	return fmt.Sprintf("invalid hex character in secret at offset %d", ref.Offset)
+}
+
+// SecretBytes holds key material. It is redacted in String(), fmt verbs and JSON
+type SecretBytes []byte
+
+// NewSecretBytesFromHex decodes hex key. Returned errors never contain characters of s.
+// Key is decoded without intermediate buffers, but s itself is immutable and can't be wiped
+func NewSecretBytesFromHex(s string) (SecretBytes, error) {
+	b, err := secretHexCodec.DecodeString(s)
+	if err != nil {
+		if hexErr, ok := err.(*InvalidHexCharError); ok {
+			return nil, &InvalidSecretHexError{Offset: hexErr.Offset}
+		}
+
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Bytes returns underlying key material
+func (ref SecretBytes) Bytes() []byte {
+	return ref
+}
+
+// Equal compares secrets in constant time
+func (ref SecretBytes) Equal(other []byte) bool {
+	return subtle.ConstantTimeCompare(ref, other) == 1
+}
+
+// Wipe zeroes backing array
+func (ref SecretBytes) Wipe() {
+	for i := range ref {
+		ref[i] = 0
+	}
+
+	runtime.KeepAlive(ref)
+}
+
+func (ref SecretBytes) String() string {
+	return redactedSecret
+}
+
+func (ref SecretBytes) GoString() string {
+	return redactedSecret
+}
+
+func (ref SecretBytes) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprint(f, redactedSecret)
+}
+
+func (ref SecretBytes) MarshalText() ([]byte, error) {
+	return []byte(redactedSecret), nil
+}
+
+func (ref SecretBytes) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redactedSecret + `"`), nil
+}
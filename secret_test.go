@@ -0,0 +1,48 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSecretBytes(t *testing.T) {
+	s, err := NewSecretBytesFromHex("0xA1B2")
+	assert.Nil(t, err)
+	assert.True(t, s.Equal([]byte{0xa1, 0xb2}))
+	assert.False(t, s.Equal([]byte{0xa1}))
+
+	assert.Equal(t, "[REDACTED] [REDACTED] [REDACTED]", fmt.Sprintf("%v %x %#v", s, s, s))
+
+	b, err := json.Marshal(struct{ Key SecretBytes }{s})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Key":"[REDACTED]"}`, string(b))
+
+	raw := s.Bytes()
+	s.Wipe()
+	assert.Equal(t, []byte{0, 0}, raw)
+}
+
+func TestNewSecretBytesFromHex_Error(t *testing.T) {
+	_, err := NewSecretBytesFromHex("a1zz")
+	assert.Equal(t, &InvalidSecretHexError{Offset: 2}, err)
+	assert.NotContains(t, err.Error(), "z")
+
+	_, err = NewSecretBytesFromHex("a1b")
+	assert.Equal(t, ErrHexOddLength, err)
+}
+
+func TestNewSecretBytesFromHex_NoIntermediateBuffer(t *testing.T) {
+	allocs := testing.AllocsPerRun(10, func() {
+		if _, err := NewSecretBytesFromHex("0x00112233 44556677"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	assert.Equal(t, float64(1), allocs)
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"math/big"
+)
+
+// SignedBigIntToByteArray converts a BigInteger to a little endian two's complement byte array.
+// Returns ErrBigIntOverflow when value does not fit into numBytes.
+func SignedBigIntToByteArray(value *big.Int, numBytes int) ([]byte, error) {
+	if numBytes <= 0 {
+		return nil, ErrBigIntOverflow
+	}
+
+	bits := uint(numBytes*8 - 1)
+	max := new(big.Int).Lsh(big.NewInt(1), bits)
+	min := new(big.Int).Neg(max)
+
+	if value.Cmp(min) < 0 || value.Cmp(max) >= 0 {
+		return nil, ErrBigIntOverflow
+	}
+
+	if value.Sign() >= 0 {
+		return BigIntToByteArray(value, numBytes), nil
+	}
+
+	unsigned := new(big.Int).Add(value, new(big.Int).Lsh(max, 1))
+
+	return BigIntToByteArray(unsigned, numBytes), nil
+}
+
+// BytesToSignedBigInteger converts a little endian two's complement byte array to a BigInteger.
+func BytesToSignedBigInteger(bytes []byte) *big.Int {
+	value := BytesToBigInteger(bytes)
+
+	if len(bytes) != 0 && bytes[len(bytes)-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(bytes)*8)))
+	}
+
+	return value
+}
+
+// BigIntToByteArrayBE converts a BigInteger to a big endian byte array.
+func BigIntToByteArrayBE(value *big.Int, numBytes int) []byte {
+	b := BigIntToByteArray(value, numBytes)
+	ReverseByteArray(b)
+
+	return b
+}
+
+// BytesToBigIntegerBE converts a big endian byte array to a BigInteger.
+func BytesToBigIntegerBE(bytes []byte) *big.Int {
+	return (&big.Int{}).SetBytes(bytes)
+}
+
+// SignedBigIntToByteArrayBE converts a BigInteger to a big endian two's complement byte array.
+// Returns ErrBigIntOverflow when value does not fit into numBytes.
+func SignedBigIntToByteArrayBE(value *big.Int, numBytes int) ([]byte, error) {
+	b, err := SignedBigIntToByteArray(value, numBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ReverseByteArray(b)
+
+	return b, nil
+}
+
+// BytesToSignedBigIntegerBE converts a big endian two's complement byte array to a BigInteger.
+func BytesToSignedBigIntegerBE(bytes []byte) *big.Int {
+	le := make([]byte, len(bytes))
+	copy(le, bytes)
+	ReverseByteArray(le)
+
+	return BytesToSignedBigInteger(le)
+}
@@ -0,0 +1,25 @@
+package str_test
+
+import (
+	"github.com/proximax-storage/go-xpx-utils/str"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestFormatterFunc_External(t *testing.T) {
+	pipes := str.FormatterFunc(func(structName string, fields []*str.Field) string {
+		values := make([]string, len(fields))
+
+		for idx, f := range fields {
+			values[idx] = f.Name() + ":" + f.Text()
+		}
+
+		return structName + "|" + strings.Join(values, "|")
+	})
+
+	assert.Equal(t,
+		"testStruct|one:Hello|key:***",
+		str.FormatStruct(pipes, "testStruct", str.NewField("one", str.StringPattern, "Hello"), str.RedactedField("key", "abcd")),
+	)
+}
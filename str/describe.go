@@ -0,0 +1,201 @@
+package str
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	tagName      = "str"
+	tagOmitEmpty = "omitempty"
+	tagSecret    = "secret"
+)
+
+type DescribeOption func(cfg *describeConfig)
+
+type describeConfig struct {
+	unexported bool
+	formatter  Formatter
+}
+
+func newDescribeConfig(options ...DescribeOption) *describeConfig {
+	cfg := &describeConfig{
+		formatter: currentFormatter(),
+	}
+
+	for _, option := range options {
+		option(cfg)
+	}
+
+	return cfg
+}
+
+// WithUnexportedFields makes Describe walk unexported fields too
+func WithUnexportedFields() DescribeOption {
+	return func(cfg *describeConfig) {
+		cfg.unexported = true
+	}
+}
+
+// WithFormatter makes Describe use provided formatter instead of global one
+func WithFormatter(f Formatter) DescribeOption {
+	return func(cfg *describeConfig) {
+		cfg.formatter = f
+	}
+}
+
+// Describe building string like StructToString by fields of struct v.
+// Field is described by `str:"name,pattern,omitempty,secret"` tag, every part of tag is optional:
+// name defaults to field name, pattern defaults to ValuePattern, omitempty skips zero values,
+// secret redacts value.
+// Fields with `str:"-"` tag are skipped
+func Describe(v interface{}, options ...DescribeOption) string {
+	cfg := newDescribeConfig(options...)
+	name, fields := describeFields(v, cfg)
+
+	if nestedTooDeep() {
+		return name + " [" + truncationMarker + "]"
+	}
+
+	return cfg.formatter.Format(name, fields)
+}
+
+// Fields returns fields of struct v which are described by `str` tags like in Describe
+func Fields(v interface{}, options ...DescribeOption) []*Field {
+	_, fields := describeFields(v, newDescribeConfig(options...))
+
+	return fields
+}
+
+func describeFields(v interface{}, cfg *describeConfig) (string, []*Field) {
+	rv := reflect.ValueOf(v)
+	root := uintptr(0)
+
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		root = rv.Pointer()
+	}
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return "", nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return "", nil
+	}
+
+	fields := structFields(rv, cfg)
+
+	for _, f := range fields {
+		f.root = root
+	}
+
+	return rv.Type().Name(), fields
+}
+
+func structFields(rv reflect.Value, cfg *describeConfig) []*Field {
+	t := rv.Type()
+	fields := make([]*Field, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if sf.PkgPath != "" && !cfg.unexported {
+			continue
+		}
+
+		tag := sf.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, pattern, omitEmpty := parseTag(tag)
+		if len(name) == 0 {
+			name = sf.Name
+		}
+
+		fv := rv.Field(i)
+
+		if omitEmpty && isZeroValue(fv) {
+			continue
+		}
+
+		fields = append(fields, NewField(name, pattern, fieldValue(fv)))
+	}
+
+	return fields
+}
+
+func parseTag(tag string) (name string, pattern FieldPattern, omitEmpty bool) {
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	pattern = ValuePattern
+	secret := false
+
+	for _, part := range parts[1:] {
+		switch {
+		case part == tagOmitEmpty:
+			omitEmpty = true
+		case part == tagSecret:
+			secret = true
+		case len(part) != 0:
+			pattern = FieldPattern(part)
+		}
+	}
+
+	if secret {
+		pattern = SecretPattern
+	}
+
+	return name, pattern, omitEmpty
+}
+
+// fieldValue returns interface value when field is exported, otherwise reflect.Value
+// which fmt prints by its underlying value
+func fieldValue(fv reflect.Value) interface{} {
+	if fv.CanInterface() {
+		return fv.Interface()
+	}
+
+	return fv
+}
+
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() == 0
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isZeroValue(v.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isZeroValue(v.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
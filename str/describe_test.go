@@ -0,0 +1,41 @@
+package str
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testDescribed struct {
+	One    string  `str:"one,s"`
+	Two    int     `str:",d"`
+	Three  float32 `str:"three,g"`
+	Four   bool
+	Five   []int       `str:"five,,omitempty"`
+	Six    interface{} `str:"-"`
+	hidden string
+}
+
+func (t *testDescribed) String() string {
+	return Describe(t)
+}
+
+func TestDescribe(t *testing.T) {
+	a := &testDescribed{One: "Hello", Two: 5432, Three: 3.14, Four: true, hidden: "secret"}
+
+	assert.Equal(t, "testDescribed [one=Hello, Two=5432, three=3.14, Four=true]", a.String())
+
+	a.Five = []int{1, 2}
+	assert.Equal(t,
+		"testDescribed [one=Hello, Two=5432, three=3.14, Four=true, five=[1 2], hidden=secret]",
+		Describe(a, WithUnexportedFields()),
+	)
+
+	assert.Equal(t, "", Describe(nil))
+	assert.Equal(t, "", Describe((*testDescribed)(nil)))
+}
+
+func TestDescribe_MatchesStructToString(t *testing.T) {
+	a := &testStruct{One: "Hello", Two: 5432, Three: 3.14, Four: true, Five: make([]int, 2), Six: nil}
+
+	assert.Equal(t, testStructStr, Describe(a))
+}
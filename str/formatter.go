@@ -0,0 +1,213 @@
+package str
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// StructKey is the key of struct name in JSON and logfmt output
+const StructKey = "_struct"
+
+// Formatter builds string from struct name and its fields. Implementations usually take
+// Name() and Text() of every field, Text() is already redacted
+type Formatter interface {
+	Format(structName string, fields []*Field) string
+}
+
+// FormatterFunc adapts function to Formatter
+type FormatterFunc func(structName string, fields []*Field) string
+
+func (ref FormatterFunc) Format(structName string, fields []*Field) string {
+	return ref(structName, fields)
+}
+
+var (
+	// TextFormatter builds `Name [a=1, b=2]`
+	TextFormatter Formatter = FormatterFunc(formatText)
+	// JSONFormatter builds `{"_struct":"Name","a":1,"b":2}`
+	JSONFormatter Formatter = FormatterFunc(formatJSON)
+	// LogfmtFormatter builds `_struct=Name a=1 b=2`
+	LogfmtFormatter Formatter = FormatterFunc(formatLogfmt)
+	// IndentFormatter builds multi-line `Name [` with every field on its own indented line
+	IndentFormatter Formatter = FormatterFunc(formatIndent)
+)
+
+var formatter = struct {
+	sync.RWMutex
+	Formatter
+}{
+	Formatter: TextFormatter,
+}
+
+// SetFormatter sets formatter which is used by StructToString and Describe
+func SetFormatter(f Formatter) {
+	formatter.Lock()
+	defer formatter.Unlock()
+
+	formatter.Formatter = f
+}
+
+func currentFormatter() Formatter {
+	formatter.RLock()
+	defer formatter.RUnlock()
+
+	return formatter.Formatter
+}
+
+func formatText(structName string, fields []*Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	values := make([]string, len(fields))
+
+	for idx, field := range fields {
+		values[idx] = field.String()
+	}
+
+	return fmt.Sprintf("%s [%s]", structName, strings.Join(values, ", "))
+}
+
+func formatIndent(structName string, fields []*Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString(structName + " [\n")
+
+	for _, field := range fields {
+		sb.WriteString("  " + field.String() + "\n")
+	}
+
+	sb.WriteString("]")
+
+	return sb.String()
+}
+
+func formatLogfmt(structName string, fields []*Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	values := make([]string, 0, len(fields)+1)
+	values = append(values, StructKey+"="+logfmtValue(structName))
+
+	for _, field := range fields {
+		values = append(values, logfmtKey(field.Name())+"="+logfmtValue(field.Text()))
+	}
+
+	return strings.Join(values, " ")
+}
+
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '=' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
+
+func logfmtValue(value string) string {
+	if len(value) == 0 || strings.IndexFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '=' || r == '"' || !unicode.IsPrint(r)
+	}) >= 0 {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+func formatJSON(structName string, fields []*Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString("{" + jsonString(StructKey) + ":" + jsonString(structName))
+
+	for _, field := range fields {
+		sb.WriteString("," + jsonString(field.Name()) + ":" + jsonValue(field))
+	}
+
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+// jsonValue keeps booleans and numbers as JSON literals, nested values of ValuePattern fields as JSON arrays
+// and objects within renderer limits, other values are rendered to JSON strings
+func jsonValue(f *Field) string {
+	r, done := f.newRenderer()
+	defer done()
+
+	return jsonField(f, r, r.depth)
+}
+
+func jsonField(f *Field, r *renderer, depth int) string {
+	if f.isRedacted() {
+		return jsonString(f.text(r, depth))
+	}
+
+	switch f.pattern {
+	case ValuePattern:
+		return r.renderJSON(reflectValue(f.value), depth)
+	case StringPattern, IntPattern, BooleanPattern, FloatPattern:
+		if literal, ok := jsonLiteral(reflectValue(f.value)); ok {
+			return literal
+		}
+	}
+
+	return jsonString(f.text(r, depth))
+}
+
+func jsonLiteral(v reflect.Value) (string, bool) {
+	if !v.IsValid() {
+		return "null", true
+	}
+
+	var literal interface{}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		literal = v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		literal = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		literal = v.Uint()
+	case reflect.Float32, reflect.Float64:
+		literal = v.Float()
+	default:
+		return "", false
+	}
+
+	if v.CanInterface() {
+		if _, ok := v.Interface().(fmt.Stringer); ok {
+			return "", false
+		}
+	}
+
+	b, err := json.Marshal(literal)
+	if err != nil {
+		return "", false
+	}
+
+	return string(b), true
+}
+
+// jsonString quotes s without HTML escaping, so markers like <cycle> stay readable
+func jsonString(s string) string {
+	sb := &strings.Builder{}
+	enc := json.NewEncoder(sb)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,88 @@
+package str
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testFormatterFields() []*Field {
+	return []*Field{
+		NewField("One", StringPattern, "Hello world"),
+		NewField("Two", IntPattern, 5432),
+		NewField("Four", BooleanPattern, true),
+		NewField("Five", ValuePattern, []int{0, 0}),
+		RedactedField("Key", "abcd"),
+	}
+}
+
+func TestFormatStruct(t *testing.T) {
+	fields := testFormatterFields()
+
+	assert.Equal(t,
+		`{"_struct":"testStruct","One":"Hello world","Two":5432,"Four":true,"Five":[0,0],"Key":"***"}`,
+		FormatStruct(JSONFormatter, "testStruct", fields...),
+	)
+
+	assert.Equal(t,
+		`_struct=testStruct One="Hello world" Two=5432 Four=true Five="[0 0]" Key=***`,
+		FormatStruct(LogfmtFormatter, "testStruct", fields...),
+	)
+
+	assert.Equal(t,
+		"testStruct [\n  One=Hello world\n  Two=5432\n  Four=true\n  Five=[0 0]\n  Key=***\n]",
+		FormatStruct(IndentFormatter, "testStruct", fields...),
+	)
+
+	assert.Equal(t, "", FormatStruct(JSONFormatter, "testStruct"))
+}
+
+func TestSetFormatter(t *testing.T) {
+	SetFormatter(LogfmtFormatter)
+	defer SetFormatter(TextFormatter)
+
+	assert.Equal(t, "_struct=testStruct One=Hello", StructToString("testStruct", NewField("One", StringPattern, "Hello")))
+
+	a := &testDescribed{One: "Hello", Two: 1}
+	assert.Equal(t, "testDescribed [one=Hello, Two=1, three=0, Four=false]", Describe(a, WithFormatter(TextFormatter)))
+	assert.Equal(t, "_struct=testDescribed one=Hello Two=1 three=0 Four=false", Describe(a))
+}
+
+type testJSONInner struct {
+	A      int    `str:"a"`
+	Secret string `str:"secret,secret"`
+}
+
+type testJSONOuter struct {
+	Inner  testJSONInner
+	Items  []*testJSONInner
+	Meta   map[string][]int
+	Nested [][][]int
+	Self   *testJSONOuter
+}
+
+func TestJSONFormatter_Nested(t *testing.T) {
+	SetLimits(Limits{MaxDepth: 2, MaxElements: 1, MaxStringLen: 256})
+	defer SetLimits(DefaultLimits)
+
+	outer := &testJSONOuter{
+		Inner:  testJSONInner{A: 1, Secret: "abcd"},
+		Items:  []*testJSONInner{{A: 2}, nil},
+		Meta:   map[string][]int{"b": {2}, "a": {1}},
+		Nested: [][][]int{{{1}}},
+	}
+	outer.Self = outer
+
+	s := Describe(outer, WithFormatter(JSONFormatter))
+
+	assert.True(t, json.Valid([]byte(s)), s)
+	assert.Equal(t,
+		`{"_struct":"testJSONOuter",`+
+			`"Inner":{"_struct":"testJSONInner","a":1,"secret":"***"},`+
+			`"Items":[{"_struct":"testJSONInner","a":2,"secret":"***"},"…(+1 more)"],`+
+			`"Meta":{"a":[1],"…":"…(+1 more)"},`+
+			`"Nested":[["[…]"]],`+
+			`"Self":"<cycle>"}`,
+		s,
+	)
+}
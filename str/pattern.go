@@ -0,0 +1,391 @@
+package str
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	HexPattern      FieldPattern = "x"
+	UpperHexPattern FieldPattern = "X"
+	QuotedPattern   FieldPattern = "q"
+	PointerPattern  FieldPattern = "p"
+	// TimePattern formats time.Time in RFC 3339 format with nanoseconds
+	TimePattern FieldPattern = "time"
+	// DurationPattern formats time.Duration like "1h2m3s"
+	DurationPattern FieldPattern = "duration"
+)
+
+// PatternMode defines what NewField does when pattern does not fit value type
+type PatternMode int
+
+const (
+	// LenientPatterns falls back to ValuePattern
+	LenientPatterns PatternMode = iota
+	// StrictPatterns panics with *PatternError, use it in tests to catch pattern drift
+	StrictPatterns
+)
+
+var patternMode = struct {
+	sync.RWMutex
+	mode PatternMode
+}{
+	mode: LenientPatterns,
+}
+
+// SetPatternMode sets global validation mode of NewField
+func SetPatternMode(mode PatternMode) {
+	patternMode.Lock()
+	defer patternMode.Unlock()
+
+	patternMode.mode = mode
+}
+
+func currentPatternMode() PatternMode {
+	patternMode.RLock()
+	defer patternMode.RUnlock()
+
+	return patternMode.mode
+}
+
+// PatternError describes pattern which does not fit value type
+type PatternError struct {
+	Field   string
+	Pattern FieldPattern
+	Type    string
+}
+
+func (ref *PatternError) Error() string {
+	return fmt.Sprintf("str: pattern %q does not fit %s value of field %s", ref.Pattern, ref.Type, ref.Field)
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	formatterType = reflect.TypeOf((*fmt.Formatter)(nil)).Elem()
+	stringerType  = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+type parsedPattern struct {
+	flags     string
+	width     string
+	precision string
+	verb      string
+}
+
+// parse splits pattern into `[flags][width][.precision]verb` parts
+func (ref FieldPattern) parse() (parsedPattern, bool) {
+	s := string(ref)
+	p := parsedPattern{}
+
+	i := scanPattern(s, 0, isFlagChar)
+	p.flags = s[:i]
+
+	j := scanPattern(s, i, isDigitChar)
+	p.width = s[i:j]
+
+	if j < len(s) && s[j] == '.' {
+		i = scanPattern(s, j+1, isDigitChar)
+		p.precision = s[j+1 : i]
+		j = i
+	}
+
+	i = scanPattern(s, j, isLetterChar)
+	p.verb = s[j:i]
+
+	if i != len(s) || len(p.verb) == 0 {
+		return parsedPattern{}, false
+	}
+
+	if len(p.verb) > 1 && FieldPattern(p.verb) != TimePattern && FieldPattern(p.verb) != DurationPattern &&
+		FieldPattern(p.verb) != SecretPattern {
+		return parsedPattern{}, false
+	}
+
+	return p, true
+}
+
+func scanPattern(s string, start int, accepts func(c byte) bool) int {
+	for start < len(s) && accepts(s[start]) {
+		start++
+	}
+
+	return start
+}
+
+func isFlagChar(c byte) bool {
+	return c == '-' || c == '+' || c == '#' || c == ' ' || c == '0'
+}
+
+func isDigitChar(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetterChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (ref parsedPattern) String() string {
+	s := ref.flags + ref.width
+
+	if len(ref.precision) != 0 {
+		s += "." + ref.precision
+	}
+
+	return s + ref.verb
+}
+
+// WithWidth returns pattern with minimal width, e.g. IntPattern.WithWidth(8) is "8d"
+func (ref FieldPattern) WithWidth(width int) FieldPattern {
+	p, ok := ref.parse()
+	if !ok {
+		return ref
+	}
+
+	p.width = strconv.Itoa(width)
+
+	return FieldPattern(p.String())
+}
+
+// WithPrecision returns pattern with precision, e.g. FloatPattern.WithPrecision(3) is ".3g"
+func (ref FieldPattern) WithPrecision(precision int) FieldPattern {
+	p, ok := ref.parse()
+	if !ok {
+		return ref
+	}
+
+	p.precision = strconv.Itoa(precision)
+
+	return FieldPattern(p.String())
+}
+
+// ValidatePattern checks that pattern fits value type. Pointers are checked by type they point to
+func ValidatePattern(pattern FieldPattern, val interface{}) error {
+	if pattern == ValuePattern || pattern == SecretPattern {
+		return nil
+	}
+
+	p, ok := pattern.parse()
+	if !ok {
+		return &PatternError{Pattern: pattern, Type: typeName(val)}
+	}
+
+	if val == nil {
+		return nil
+	}
+
+	if _, ok := val.(reflect.Value); ok {
+		return nil
+	}
+
+	v := derefForPattern(reflect.ValueOf(val), p.verb)
+	if !v.IsValid() {
+		return nil
+	}
+
+	if !cachedPatternAccepts(p.verb, v.Type()) {
+		return &PatternError{Pattern: pattern, Type: v.Type().String()}
+	}
+
+	return nil
+}
+
+func typeName(val interface{}) string {
+	if val == nil {
+		return "nil"
+	}
+
+	return reflect.TypeOf(val).String()
+}
+
+// derefForPattern dereferences pointers for patterns which don't print pointers themselves.
+// Pointers implementing fmt.Formatter, fmt.Stringer or error are kept as is for fmt verbs
+func derefForPattern(v reflect.Value, verb string) reflect.Value {
+	if verb == string(PointerPattern) || verb == string(ValuePattern) || verb == string(SecretPattern) {
+		return v
+	}
+
+	custom := len(verb) > 1
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		if !custom && implementsAny(v.Type(), formatterType, stringerType, errorType) {
+			return v
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func implementsAny(t reflect.Type, interfaces ...reflect.Type) bool {
+	for _, i := range interfaces {
+		if t.Implements(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type patternKey struct {
+	verb string
+	typ  reflect.Type
+}
+
+// acceptedPatterns caches results of patternAccepts by patternKey
+var acceptedPatterns sync.Map
+
+func cachedPatternAccepts(verb string, t reflect.Type) bool {
+	key := patternKey{verb: verb, typ: t}
+
+	if accepted, ok := acceptedPatterns.Load(key); ok {
+		return accepted.(bool)
+	}
+
+	accepted := patternAccepts(verb, t, map[reflect.Type]bool{})
+	acceptedPatterns.Store(key, accepted)
+
+	return accepted
+}
+
+// patternAccepts checks is verb applicable to values of type t. Composite types which are already being checked
+// are accepted, so self-referencing types don't recurse infinitely, their other fields decide
+func patternAccepts(verb string, t reflect.Type, visiting map[reflect.Type]bool) bool {
+	switch FieldPattern(verb) {
+	case ValuePattern, SecretPattern:
+		return true
+	case TimePattern:
+		return t == timeType
+	case DurationPattern:
+		return t == durationType
+	case PointerPattern:
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			return true
+		}
+
+		return false
+	}
+
+	if t.Implements(formatterType) {
+		return true
+	}
+
+	textual := implementsAny(t, stringerType, errorType)
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		if visiting[t] {
+			return true
+		}
+
+		visiting[t] = true
+		defer delete(visiting, t)
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && (verb == "s" || verb == "q" || verb == "x" || verb == "X") {
+			return true
+		}
+
+		return patternAccepts(verb, t.Elem(), visiting)
+	case reflect.Map:
+		return patternAccepts(verb, t.Key(), visiting) && patternAccepts(verb, t.Elem(), visiting)
+	case reflect.Struct:
+		if textual && isTextualVerb(verb) {
+			return true
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			if !patternAccepts(verb, t.Field(i).Type, visiting) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Interface:
+		return true
+	}
+
+	switch verb {
+	case "s":
+		return textual || t.Kind() == reflect.String
+	case "q":
+		return textual || t.Kind() == reflect.String || isInteger(t)
+	case "x", "X":
+		return textual || t.Kind() == reflect.String || isInteger(t) || isFloat(t)
+	case "d", "b", "o", "O", "c", "U":
+		return isInteger(t)
+	case "t":
+		return t.Kind() == reflect.Bool
+	case "e", "E", "f", "F", "g", "G":
+		return isFloat(t)
+	}
+
+	return false
+}
+
+func isTextualVerb(verb string) bool {
+	return verb == "s" || verb == "q" || verb == "x" || verb == "X"
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+func isFloat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+
+	return false
+}
+
+// formatPattern formats value which passed ValidatePattern
+func formatPattern(pattern FieldPattern, val interface{}) string {
+	p, ok := pattern.parse()
+	if !ok {
+		return fmt.Sprintf("%v", val)
+	}
+
+	v := reflect.ValueOf(val)
+	if rv, ok := val.(reflect.Value); ok {
+		v = rv
+	}
+
+	v = derefForPattern(v, p.verb)
+	if !v.IsValid() {
+		return nilValue
+	}
+
+	var arg interface{} = v
+	if v.CanInterface() {
+		arg = v.Interface()
+	}
+
+	switch FieldPattern(p.verb) {
+	case TimePattern:
+		return fmt.Sprintf("%"+p.flags+p.width+"s", arg.(time.Time).Format(time.RFC3339Nano))
+	case DurationPattern:
+		return fmt.Sprintf("%"+p.flags+p.width+"s", arg.(time.Duration).String())
+	}
+
+	return fmt.Sprintf("%"+string(pattern), arg)
+}
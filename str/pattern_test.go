@@ -0,0 +1,92 @@
+package str
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestNewField_Patterns(t *testing.T) {
+	n := 255
+	when := time.Date(2019, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, "a=ff", NewField("a", HexPattern, n).String())
+	assert.Equal(t, "a=FF", NewField("a", UpperHexPattern, &n).String())
+	assert.Equal(t, `a="hi"`, NewField("a", QuotedPattern, "hi").String())
+	assert.Equal(t, "a=6869", NewField("a", HexPattern, []byte("hi")).String())
+	assert.Equal(t, "a=2019-01-02T03:04:05Z", NewField("a", TimePattern, &when).String())
+	assert.Equal(t, "a=1m30s", NewField("a", DurationPattern, 90*time.Second).String())
+	assert.Equal(t, "a=     255", NewField("a", IntPattern.WithWidth(8), n).String())
+	assert.Equal(t, "a=3.14", NewField("a", FloatPattern.WithPrecision(3), 3.14159).String())
+	assert.Equal(t, "a=  3.142", NewField("a", FloatPattern.WithPrecision(4).WithWidth(7), 3.14159).String())
+	assert.Equal(t, "a=12345678901234567890", NewField("a", IntPattern, new(big.Int).SetUint64(12345678901234567890)).String())
+	assert.Equal(t, "a=<nil>", NewField("a", IntPattern, (*int)(nil)).String())
+}
+
+func TestNewField_Lenient(t *testing.T) {
+	assert.Equal(t, "a=text", NewField("a", IntPattern, "text").String())
+	assert.Equal(t, "a=5", NewField("a", TimePattern, 5).String())
+	assert.Equal(t, "a=true", NewField("a", FieldPattern("unknown"), true).String())
+}
+
+func TestNewField_Strict(t *testing.T) {
+	SetPatternMode(StrictPatterns)
+	defer SetPatternMode(LenientPatterns)
+
+	func() {
+		defer func() {
+			assert.Equal(t, &PatternError{Field: "a", Pattern: IntPattern, Type: "string"}, recover())
+		}()
+
+		NewField("a", IntPattern, "text")
+	}()
+
+	assert.NotPanics(t, func() {
+		NewField("a", IntPattern, []int{1, 2})
+		NewField("a", StringPattern, time.Second)
+	})
+}
+
+func TestValidatePattern(t *testing.T) {
+	assert.Nil(t, ValidatePattern(BooleanPattern, true))
+	assert.Nil(t, ValidatePattern(PointerPattern, &struct{}{}))
+	assert.NotNil(t, ValidatePattern(PointerPattern, 1))
+	assert.NotNil(t, ValidatePattern(FloatPattern, 1))
+	assert.NotNil(t, ValidatePattern(DurationPattern, int64(1)))
+}
+
+type testPatternNode struct {
+	Value    int
+	Children []testPatternNode
+	Index    map[int]testPatternNode
+}
+
+func TestValidatePattern_SelfReferencing(t *testing.T) {
+	assert.Nil(t, ValidatePattern(IntPattern, testPatternNode{}))
+	assert.NotNil(t, ValidatePattern(BooleanPattern, testPatternNode{}))
+	assert.Equal(t, "n={1 [{2 [] map[]}] map[]}", NewField("n", IntPattern, testPatternNode{Value: 1, Children: []testPatternNode{{Value: 2}}}).String())
+}
+
+func TestFieldPattern_Parse(t *testing.T) {
+	p, ok := FieldPattern("-08.3f").parse()
+	assert.True(t, ok)
+	assert.Equal(t, parsedPattern{flags: "-0", width: "8", precision: "3", verb: "f"}, p)
+
+	p, ok = FieldPattern(".g").parse()
+	assert.True(t, ok)
+	assert.Equal(t, parsedPattern{verb: "g"}, p)
+
+	for _, pattern := range []FieldPattern{"", "8", "8.3", "d8", "%d", "abc"} {
+		_, ok = pattern.parse()
+		assert.False(t, ok, pattern)
+	}
+}
+
+func BenchmarkNewField(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewField("a", IntPattern, i)
+	}
+}
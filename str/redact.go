@@ -0,0 +1,154 @@
+package str
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// SecretPattern hides field value according to current RedactionMode
+const SecretPattern FieldPattern = "secret"
+
+const (
+	redactedMask      = "***"
+	fingerprintPrefix = "hmac:"
+	fingerprintSize   = 4
+	fingerprintKeyLen = 32
+)
+
+// RedactionMode defines how redacted values are rendered
+type RedactionMode int
+
+const (
+	// RedactMask renders redacted value as ***
+	RedactMask RedactionMode = iota
+	// RedactFingerprint renders redacted value as short HMAC-SHA256 fingerprint, so equal values can be matched in logs.
+	// Fingerprints are keyed by random per-process key unless key is set by SetFingerprintKey
+	RedactFingerprint
+)
+
+var redaction = struct {
+	sync.RWMutex
+	mode  RedactionMode
+	key   []byte
+	names map[string]struct{}
+}{
+	mode: RedactMask,
+	key:  newFingerprintKey(),
+	names: map[string]struct{}{
+		"password":      {},
+		"privatekey":    {},
+		"secret":        {},
+		"token":         {},
+		"authorization": {},
+	},
+}
+
+// RedactedField returns field which value is hidden in output
+func RedactedField(fieldName string, val interface{}) *Field {
+	return NewField(fieldName, SecretPattern, val)
+}
+
+// RegisterSensitiveFields adds names of fields which are redacted regardless of pattern.
+// Names are matched case insensitive ignoring '_' and '-'
+func RegisterSensitiveFields(names ...string) {
+	redaction.Lock()
+	defer redaction.Unlock()
+
+	for _, name := range names {
+		redaction.names[normalizeFieldName(name)] = struct{}{}
+	}
+}
+
+// UnregisterSensitiveFields removes names added by RegisterSensitiveFields or registered by default
+func UnregisterSensitiveFields(names ...string) {
+	redaction.Lock()
+	defer redaction.Unlock()
+
+	for _, name := range names {
+		delete(redaction.names, normalizeFieldName(name))
+	}
+}
+
+// IsSensitiveField checks does name belong to registry of sensitive fields
+func IsSensitiveField(name string) bool {
+	redaction.RLock()
+	defer redaction.RUnlock()
+
+	_, ok := redaction.names[normalizeFieldName(name)]
+
+	return ok
+}
+
+// SetRedactionMode sets global rendering of redacted values
+func SetRedactionMode(mode RedactionMode) {
+	redaction.Lock()
+	defer redaction.Unlock()
+
+	redaction.mode = mode
+}
+
+// SetFingerprintKey sets key of fingerprints, so fingerprints of equal values can be matched between processes
+func SetFingerprintKey(key []byte) {
+	redaction.Lock()
+	defer redaction.Unlock()
+
+	redaction.key = append([]byte(nil), key...)
+}
+
+// Redact renders value according to current RedactionMode
+func Redact(value interface{}) string {
+	redaction.RLock()
+	mode, key := redaction.mode, redaction.key
+	redaction.RUnlock()
+
+	if mode == RedactFingerprint {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(fingerprintData(value))
+
+		return fingerprintPrefix + hex.EncodeToString(mac.Sum(nil)[:fingerprintSize])
+	}
+
+	return redactedMask
+}
+
+// fingerprintData returns raw content of byte slices and strings, because secret types like utils.SecretBytes
+// print the same placeholder for any value
+func fingerprintData(value interface{}) []byte {
+	rv, ok := value.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(value)
+	}
+
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	switch {
+	case rv.Kind() == reflect.String:
+		return []byte(rv.String())
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return rv.Bytes()
+	}
+
+	return []byte(fmt.Sprintf("%v", value))
+}
+
+func newFingerprintKey() []byte {
+	key := make([]byte, fingerprintKeyLen)
+
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
@@ -0,0 +1,71 @@
+package str
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/proximax-storage/go-xpx-utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testAccount struct {
+	Address    string `str:"address,s"`
+	PrivateKey string
+	Seed       string `str:"seed,s,secret"`
+}
+
+func TestRedactedField(t *testing.T) {
+	assert.Equal(t,
+		"testStruct [one=Hello, key=***]",
+		StructToString("testStruct", NewField("one", StringPattern, "Hello"), RedactedField("key", "abcd")),
+	)
+}
+
+func TestDescribe_Redacted(t *testing.T) {
+	a := &testAccount{Address: "SAONSO", PrivateKey: "abcd", Seed: "words"}
+
+	assert.Equal(t, "testAccount [address=SAONSO, PrivateKey=***, seed=***]", Describe(a))
+
+	RegisterSensitiveFields("address")
+	defer UnregisterSensitiveFields("address")
+
+	assert.Equal(t, "testAccount [address=***, PrivateKey=***, seed=***]", Describe(a))
+}
+
+func TestRedact_Fingerprint(t *testing.T) {
+	SetRedactionMode(RedactFingerprint)
+	defer SetRedactionMode(RedactMask)
+
+	assert.Equal(t, Redact("abcd"), Redact("abcd"))
+	assert.NotEqual(t, Redact("abcd"), Redact("abce"))
+	assert.Equal(t, "key="+Redact("abcd"), RedactedField("key", "abcd").String())
+	assert.Len(t, Redact("abcd"), len("hmac:")+8)
+}
+
+func TestRedact_FingerprintKeyed(t *testing.T) {
+	SetRedactionMode(RedactFingerprint)
+	defer SetRedactionMode(RedactMask)
+
+	plain := sha256.Sum256([]byte("abcd"))
+	assert.NotEqual(t, "hmac:"+hex.EncodeToString(plain[:4]), Redact("abcd"))
+
+	key := []byte("key")
+	SetFingerprintKey(key)
+	defer SetFingerprintKey(newFingerprintKey())
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("abcd"))
+	assert.Equal(t, "hmac:"+hex.EncodeToString(mac.Sum(nil)[:4]), Redact("abcd"))
+}
+
+func TestRedact_FingerprintSecretBytes(t *testing.T) {
+	SetRedactionMode(RedactFingerprint)
+	defer SetRedactionMode(RedactMask)
+
+	a, b := utils.SecretBytes{0x01, 0x02}, utils.SecretBytes{0x01, 0x03}
+
+	assert.NotEqual(t, Redact(a), Redact(b))
+	assert.Equal(t, Redact(a), Redact(utils.SecretBytes{0x01, 0x02}))
+	assert.NotEqual(t, RedactedField("key", a).String(), RedactedField("key", b).String())
+}
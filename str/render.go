@@ -0,0 +1,512 @@
+package str
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+const (
+	nilValue         = "<nil>"
+	cycleValue       = "<cycle>"
+	truncationMarker = "…"
+)
+
+// Limits bounds rendering of nested values by ValuePattern
+type Limits struct {
+	// MaxDepth is how many levels of nested structs, slices and maps are rendered
+	MaxDepth int
+	// MaxElements is how many elements of slice or map are rendered
+	MaxElements int
+	// MaxStringLen is how many characters of string are rendered
+	MaxStringLen int
+}
+
+var DefaultLimits = Limits{
+	MaxDepth:     4,
+	MaxElements:  16,
+	MaxStringLen: 256,
+}
+
+var limits = struct {
+	sync.RWMutex
+	Limits
+}{
+	Limits: DefaultLimits,
+}
+
+// SetLimits sets global limits of rendering nested values
+func SetLimits(l Limits) {
+	limits.Lock()
+	defer limits.Unlock()
+
+	limits.Limits = l
+}
+
+func currentLimits() Limits {
+	limits.RLock()
+	defer limits.RUnlock()
+
+	return limits.Limits
+}
+
+// renderer renders values in `Name [k=v]` style. Stringers and errors are rendered by their own methods,
+// pointers, slices and maps which are already being rendered are replaced by <cycle>.
+// Describe and StructToString called from those methods continue rendering of the same renderer,
+// so cycles and depth are tracked through String() methods too
+type renderer struct {
+	limits   Limits
+	visiting map[uintptr]bool
+	// depth is depth of values rendered by renderer started within String() method
+	depth int
+}
+
+// activeRenderer is renderer which calls String() method of value at depth
+type activeRenderer struct {
+	renderer *renderer
+	depth    int
+}
+
+// active holds renderers which are calling String() methods by goroutine id.
+// count allows to skip lookup of goroutine id when no renderer is active
+var active = struct {
+	sync.Mutex
+	count     int32
+	renderers map[uint64]*activeRenderer
+}{
+	renderers: make(map[uint64]*activeRenderer),
+}
+
+// newRenderer returns renderer which continues active renderer of current goroutine, or new one
+func newRenderer() *renderer {
+	if parent := currentActiveRenderer(); parent != nil {
+		return &renderer{
+			limits:   parent.renderer.limits,
+			visiting: parent.renderer.visiting,
+			depth:    parent.depth + 1,
+		}
+	}
+
+	return &renderer{
+		limits:   currentLimits(),
+		visiting: make(map[uintptr]bool),
+	}
+}
+
+func currentActiveRenderer() *activeRenderer {
+	if atomic.LoadInt32(&active.count) == 0 {
+		return nil
+	}
+
+	id := goroutineID()
+
+	active.Lock()
+	defer active.Unlock()
+
+	return active.renderers[id]
+}
+
+// nestedTooDeep checks is struct going to be rendered within String() method deeper than limits allow
+func nestedTooDeep() bool {
+	parent := currentActiveRenderer()
+
+	return parent != nil && parent.depth >= parent.renderer.limits.MaxDepth
+}
+
+// callStringer calls fn with ref registered as active renderer of current goroutine
+func (ref *renderer) callStringer(fn func() string, depth int) string {
+	id := goroutineID()
+
+	active.Lock()
+	prev := active.renderers[id]
+	active.renderers[id] = &activeRenderer{renderer: ref, depth: depth}
+	active.Unlock()
+	atomic.AddInt32(&active.count, 1)
+
+	defer func() {
+		active.Lock()
+		if prev != nil {
+			active.renderers[id] = prev
+		} else {
+			delete(active.renderers, id)
+		}
+		active.Unlock()
+		atomic.AddInt32(&active.count, -1)
+	}()
+
+	return fn()
+}
+
+// goroutineID parses id of current goroutine from the header of its stack trace
+func goroutineID() uint64 {
+	var buf [64]byte
+
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if idx := bytes.IndexByte(b, ' '); idx >= 0 {
+		b = b[:idx]
+	}
+
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+
+	return id
+}
+
+func (ref *renderer) renderInterface(value interface{}, depth int) string {
+	return ref.render(reflectValue(value), depth)
+}
+
+func (ref *renderer) render(v reflect.Value, depth int) string {
+	if !v.IsValid() {
+		return nilValue
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return ref.renderNil(v)
+		}
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if ref.visiting[v.Pointer()] {
+			return cycleValue
+		}
+
+		ref.visiting[v.Pointer()] = true
+		defer delete(ref.visiting, v.Pointer())
+	}
+
+	if fn, ok := stringerOf(v); ok {
+		return ref.truncateString(ref.callStringer(fn, depth))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return ref.render(v.Elem(), depth)
+
+	case reflect.Interface:
+		return ref.render(v.Elem(), depth)
+
+	case reflect.String:
+		return ref.truncateString(v.String())
+
+	case reflect.Slice, reflect.Array:
+		return ref.renderList(v, depth)
+
+	case reflect.Map:
+		return ref.renderMap(v, depth)
+
+	case reflect.Struct:
+		return ref.renderStruct(v, depth)
+	}
+
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+
+	return fmt.Sprint(v)
+}
+
+// reflectValue returns reflect.Value of value, reflect.Value of unexported field is returned as is
+func reflectValue(value interface{}) reflect.Value {
+	if v, ok := value.(reflect.Value); ok {
+		return v
+	}
+
+	return reflect.ValueOf(value)
+}
+
+func stringerOf(v reflect.Value) (func() string, bool) {
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		v = v.Addr()
+	}
+
+	if !v.CanInterface() {
+		return nil, false
+	}
+
+	switch s := v.Interface().(type) {
+	case error:
+		return s.Error, true
+	case fmt.Stringer:
+		return s.String, true
+	}
+
+	return nil, false
+}
+
+func (ref *renderer) renderNil(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		return "[]"
+	case reflect.Map:
+		return "map[]"
+	}
+
+	return nilValue
+}
+
+func (ref *renderer) renderList(v reflect.Value, depth int) string {
+	if depth >= ref.limits.MaxDepth {
+		return "[" + truncationMarker + "]"
+	}
+
+	if v.Kind() == reflect.Slice && v.Len() > 0 {
+		if ref.visiting[v.Pointer()] {
+			return cycleValue
+		}
+
+		ref.visiting[v.Pointer()] = true
+		defer delete(ref.visiting, v.Pointer())
+	}
+
+	n := v.Len()
+	shown := ref.shownElements(n)
+	values := make([]string, 0, shown+1)
+
+	for i := 0; i < shown; i++ {
+		values = append(values, ref.render(v.Index(i), depth+1))
+	}
+
+	if shown < n {
+		values = append(values, moreMarker(n-shown))
+	}
+
+	return "[" + strings.Join(values, " ") + "]"
+}
+
+func (ref *renderer) renderMap(v reflect.Value, depth int) string {
+	if depth >= ref.limits.MaxDepth {
+		return "map[" + truncationMarker + "]"
+	}
+
+	if ref.visiting[v.Pointer()] {
+		return cycleValue
+	}
+
+	ref.visiting[v.Pointer()] = true
+	defer delete(ref.visiting, v.Pointer())
+
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+
+	entries := make([]entry, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		entries = append(entries, entry{key: ref.render(key, depth+1), value: v.MapIndex(key)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	n := len(entries)
+	shown := ref.shownElements(n)
+	values := make([]string, 0, shown+1)
+
+	for _, e := range entries[:shown] {
+		values = append(values, e.key+":"+ref.render(e.value, depth+1))
+	}
+
+	if shown < n {
+		values = append(values, moreMarker(n-shown))
+	}
+
+	return "map[" + strings.Join(values, " ") + "]"
+}
+
+func (ref *renderer) renderStruct(v reflect.Value, depth int) string {
+	name := v.Type().Name()
+
+	if depth >= ref.limits.MaxDepth {
+		return name + " [" + truncationMarker + "]"
+	}
+
+	fields := structFields(v, &describeConfig{})
+	values := make([]string, len(fields))
+
+	for idx, f := range fields {
+		values[idx] = f.render(ref, depth+1)
+	}
+
+	return fmt.Sprintf("%s [%s]", name, strings.Join(values, ", "))
+}
+
+// renderJSON renders value like render, but as JSON. Slices and arrays are JSON arrays, maps and structs are
+// JSON objects, structs have their name under StructKey. Values cut by limits are JSON strings with markers
+func (ref *renderer) renderJSON(v reflect.Value, depth int) string {
+	if !v.IsValid() {
+		return "null"
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return "[]"
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return "{}"
+		}
+	case reflect.Ptr, reflect.Interface, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return "null"
+		}
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if ref.visiting[v.Pointer()] {
+			return jsonString(cycleValue)
+		}
+
+		ref.visiting[v.Pointer()] = true
+		defer delete(ref.visiting, v.Pointer())
+	}
+
+	if fn, ok := stringerOf(v); ok {
+		return jsonString(ref.truncateString(ref.callStringer(fn, depth)))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return ref.renderJSON(v.Elem(), depth)
+
+	case reflect.String:
+		return jsonString(ref.truncateString(v.String()))
+
+	case reflect.Slice, reflect.Array:
+		return ref.renderJSONList(v, depth)
+
+	case reflect.Map:
+		return ref.renderJSONMap(v, depth)
+
+	case reflect.Struct:
+		return ref.renderJSONStruct(v, depth)
+	}
+
+	if literal, ok := jsonLiteral(v); ok {
+		return literal
+	}
+
+	return jsonString(ref.render(v, depth))
+}
+
+func (ref *renderer) renderJSONList(v reflect.Value, depth int) string {
+	if depth >= ref.limits.MaxDepth {
+		return jsonString("[" + truncationMarker + "]")
+	}
+
+	if v.Kind() == reflect.Slice && v.Len() > 0 {
+		if ref.visiting[v.Pointer()] {
+			return jsonString(cycleValue)
+		}
+
+		ref.visiting[v.Pointer()] = true
+		defer delete(ref.visiting, v.Pointer())
+	}
+
+	n := v.Len()
+	shown := ref.shownElements(n)
+	values := make([]string, 0, shown+1)
+
+	for i := 0; i < shown; i++ {
+		values = append(values, ref.renderJSON(v.Index(i), depth+1))
+	}
+
+	if shown < n {
+		values = append(values, jsonString(moreMarker(n-shown)))
+	}
+
+	return "[" + strings.Join(values, ",") + "]"
+}
+
+func (ref *renderer) renderJSONMap(v reflect.Value, depth int) string {
+	if depth >= ref.limits.MaxDepth {
+		return jsonString("map[" + truncationMarker + "]")
+	}
+
+	if ref.visiting[v.Pointer()] {
+		return jsonString(cycleValue)
+	}
+
+	ref.visiting[v.Pointer()] = true
+	defer delete(ref.visiting, v.Pointer())
+
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+
+	entries := make([]entry, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		entries = append(entries, entry{key: ref.render(key, depth+1), value: v.MapIndex(key)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	n := len(entries)
+	shown := ref.shownElements(n)
+	values := make([]string, 0, shown+1)
+
+	for _, e := range entries[:shown] {
+		values = append(values, jsonString(e.key)+":"+ref.renderJSON(e.value, depth+1))
+	}
+
+	if shown < n {
+		values = append(values, jsonString(truncationMarker)+":"+jsonString(moreMarker(n-shown)))
+	}
+
+	return "{" + strings.Join(values, ",") + "}"
+}
+
+func (ref *renderer) renderJSONStruct(v reflect.Value, depth int) string {
+	name := v.Type().Name()
+
+	if depth >= ref.limits.MaxDepth {
+		return jsonString(name + " [" + truncationMarker + "]")
+	}
+
+	fields := structFields(v, &describeConfig{})
+	values := make([]string, 0, len(fields)+1)
+	values = append(values, jsonString(StructKey)+":"+jsonString(name))
+
+	for _, f := range fields {
+		values = append(values, jsonString(f.fieldName)+":"+jsonField(f, ref, depth+1))
+	}
+
+	return "{" + strings.Join(values, ",") + "}"
+}
+
+func (ref *renderer) shownElements(n int) int {
+	if ref.limits.MaxElements > 0 && n > ref.limits.MaxElements {
+		return ref.limits.MaxElements
+	}
+
+	return n
+}
+
+func (ref *renderer) truncateString(s string) string {
+	max := ref.limits.MaxStringLen
+
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	runes := []rune(s)
+
+	return string(runes[:max]) + moreMarker(len(runes)-max)
+}
+
+func moreMarker(n int) string {
+	return fmt.Sprintf("%s(+%d more)", truncationMarker, n)
+}
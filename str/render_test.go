@@ -0,0 +1,142 @@
+package str
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type testMosaic struct {
+	Id     uint64 `str:"id,d"`
+	Amount uint64 `str:"amount,d"`
+}
+
+type testNamed struct {
+	Name string
+}
+
+func (t *testNamed) String() string {
+	return "named:" + t.Name
+}
+
+type testTransfer struct {
+	Mosaics    []testMosaic
+	Named      []*testNamed
+	Meta       map[string]int
+	Message    string
+	Err        error
+	PrivateKey string
+}
+
+type testNode struct {
+	Value int
+	Next  *testNode
+}
+
+func TestDescribe_Nested(t *testing.T) {
+	tx := &testTransfer{
+		Mosaics:    []testMosaic{{Id: 1, Amount: 10}, {Id: 2, Amount: 20}},
+		Named:      []*testNamed{{Name: "a"}, nil},
+		Meta:       map[string]int{"b": 2, "a": 1},
+		Message:    "hi",
+		Err:        errors.New("failed"),
+		PrivateKey: "abcd",
+	}
+
+	assert.Equal(t,
+		"testTransfer [Mosaics=[testMosaic [id=1, amount=10] testMosaic [id=2, amount=20]], "+
+			"Named=[named:a <nil>], Meta=map[a:1 b:2], Message=hi, Err=failed, PrivateKey=***]",
+		Describe(tx),
+	)
+}
+
+func TestDescribe_Limits(t *testing.T) {
+	SetLimits(Limits{MaxDepth: 2, MaxElements: 2, MaxStringLen: 3})
+	defer SetLimits(DefaultLimits)
+
+	tx := &testTransfer{
+		Mosaics: []testMosaic{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}},
+		Message: "hello",
+	}
+
+	s := Describe(tx)
+
+	assert.True(t, strings.Contains(s, "Mosaics=[testMosaic [id=1, amount=0] testMosaic [id=2, amount=0] …(+2 more)]"), s)
+	assert.True(t, strings.Contains(s, "Message=hel…(+2 more)"), s)
+
+	nested := StructToString("outer", NewField("nested", ValuePattern, [][][]int{{{1}}}))
+	assert.Equal(t, "outer [nested=[[[…]]]]", nested)
+}
+
+func TestDescribe_Cycle(t *testing.T) {
+	node := &testNode{Value: 1}
+	node.Next = &testNode{Value: 2, Next: node}
+
+	assert.Equal(t, "testNode [Value=1, Next=testNode [Value=2, Next=<cycle>]]", Describe(node))
+	assert.Equal(t, "node=testNode [Value=1, Next=testNode [Value=2, Next=<cycle>]]", NewField("node", ValuePattern, node).String())
+}
+
+type testBlock struct {
+	Height uint64
+	Txs    []*testBlockTx
+}
+
+func (b *testBlock) String() string {
+	return Describe(b)
+}
+
+type testBlockTx struct {
+	Hash  string
+	Block *testBlock
+}
+
+func (t *testBlockTx) String() string {
+	return StructToString("testBlockTx", NewField("Hash", StringPattern, t.Hash), NewField("Block", ValuePattern, t.Block))
+}
+
+type testChain struct {
+	Value int
+	Next  *testChain
+}
+
+func (c *testChain) String() string {
+	return Describe(c)
+}
+
+func TestDescribe_CycleThroughStringers(t *testing.T) {
+	block := &testBlock{Height: 1}
+	tx := &testBlockTx{Hash: "ab", Block: block}
+	block.Txs = []*testBlockTx{tx}
+
+	assert.Equal(t, "testBlock [Height=1, Txs=[testBlockTx [Hash=ab, Block=<cycle>]]]", block.String())
+	// StructToString does not know pointer of described struct, so cycle is detected one level deeper
+	assert.Equal(t, "testBlockTx [Hash=ab, Block=testBlock [Height=1, Txs=[testBlockTx [Hash=ab, Block=<cycle>]]]]", tx.String())
+	assert.Equal(t, block.String(), block.String())
+
+	want := block.String()
+	results := make(chan string, 8)
+
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			results <- block.String()
+		}()
+	}
+
+	for i := 0; i < cap(results); i++ {
+		assert.Equal(t, want, <-results)
+	}
+}
+
+func TestDescribe_DepthThroughStringers(t *testing.T) {
+	chain := &testChain{Value: 1}
+	for i := 2; i <= 10; i++ {
+		chain = &testChain{Value: i, Next: chain}
+	}
+
+	assert.Equal(t,
+		"testChain [Value=10, Next=testChain [Value=9, Next=testChain [Value=8, Next=testChain [Value=7, "+
+			"Next=testChain [Value=6, Next=testChain […]]]]]]",
+		chain.String(),
+	)
+}
@@ -1,10 +1,5 @@
 package str
 
-import (
-	"fmt"
-	"strings"
-)
-
 type FieldPattern string
 
 const (
@@ -15,37 +10,112 @@ const (
 	ValuePattern   FieldPattern = "v"
 )
 
-type field struct {
+// Field is named value of struct rendered by StructToString and Formatter implementations
+type Field struct {
 	fieldName string
 	pattern   FieldPattern
 	value     interface{}
+	// root is pointer to struct described by Describe, it is treated as already visited while rendering
+	root uintptr
 }
 
-// NewField returns field which is used for StructToString() function
-func NewField(fieldName string, pattern FieldPattern, val interface{}) *field {
-	return &field{
+// NewField returns field which is used for StructToString() function.
+// Pattern which does not fit value type is replaced by ValuePattern in LenientPatterns mode
+// and causes panic with *PatternError in StrictPatterns mode
+func NewField(fieldName string, pattern FieldPattern, val interface{}) *Field {
+	if err := ValidatePattern(pattern, val); err != nil {
+		if currentPatternMode() == StrictPatterns {
+			err.(*PatternError).Field = fieldName
+			panic(err)
+		}
+
+		pattern = ValuePattern
+	}
+
+	return &Field{
 		fieldName: fieldName,
 		pattern:   pattern,
 		value:     val,
 	}
 }
 
-func (f *field) String() string {
-	return fmt.Sprintf(f.fieldName+"=%"+string(f.pattern), f.value)
+// Name returns name of field
+func (f *Field) Name() string {
+	return f.fieldName
 }
 
-// StructToString building string by fields from object you provided
-// Use it within implementation fmt.Stringer interface
-func StructToString(structName string, fields ...*field) string {
-	if len(fields) == 0 {
-		return ""
+// Pattern returns pattern of field
+func (f *Field) Pattern() FieldPattern {
+	return f.pattern
+}
+
+// Value returns raw value of field, it is not redacted
+func (f *Field) Value() interface{} {
+	return f.value
+}
+
+// Text returns rendered value of field, it is redacted when field is secret
+func (f *Field) Text() string {
+	r, done := f.newRenderer()
+	defer done()
+
+	return f.text(r, r.depth)
+}
+
+func (f *Field) String() string {
+	r, done := f.newRenderer()
+	defer done()
+
+	return f.render(r, r.depth)
+}
+
+// newRenderer returns renderer with root marked as visited and function which unmarks it
+func (f *Field) newRenderer() (*renderer, func()) {
+	r := newRenderer()
+
+	if f.root == 0 || r.visiting[f.root] {
+		return r, func() {}
 	}
 
-	values := make([]string, len(fields))
+	r.visiting[f.root] = true
 
-	for idx, field := range fields {
-		values[idx] = field.String()
+	return r, func() {
+		delete(r.visiting, f.root)
 	}
+}
+
+// render renders nested values of ValuePattern fields in the same `Name [k=v]` style within renderer limits
+func (f *Field) render(r *renderer, depth int) string {
+	return f.fieldName + "=" + f.text(r, depth)
+}
+
+func (f *Field) text(r *renderer, depth int) string {
+	if f.isRedacted() {
+		return Redact(f.value)
+	}
+
+	if f.pattern == ValuePattern {
+		return r.renderInterface(f.value, depth)
+	}
+
+	return formatPattern(f.pattern, f.value)
+}
+
+func (f *Field) isRedacted() bool {
+	return f.pattern == SecretPattern || IsSensitiveField(f.fieldName)
+}
+
+// StructToString building string by fields from object you provided
+// Use it within implementation fmt.Stringer interface
+func StructToString(structName string, fields ...*Field) string {
+	if nestedTooDeep() {
+		return structName + " [" + truncationMarker + "]"
+	}
+
+	return currentFormatter().Format(structName, fields)
+}
 
-	return fmt.Sprintf("%s [%s]", structName, strings.Join(values, ", "))
+// FormatStruct building string by fields like StructToString, but with provided formatter
+func FormatStruct(formatter Formatter, structName string, fields ...*Field) string {
+	return formatter.Format(structName, fields)
 }
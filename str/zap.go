@@ -0,0 +1,152 @@
+package str
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldLister is implemented by types which describe themselves by fields,
+// usually the same fields are passed to StructToString in String()
+type FieldLister interface {
+	StrFields() []*Field
+}
+
+// FieldList is list of fields which can be logged as structured object, e.g. zap.Object("tx", FieldList(fields))
+type FieldList []*Field
+
+func (ref FieldList) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalFields(enc, ref, 0)
+}
+
+// Marshaler returns zapcore.ObjectMarshaler of v. Fields are taken from FieldLister when v implements it,
+// otherwise they are described by `str` tags like in Describe
+func Marshaler(v interface{}) zapcore.ObjectMarshaler {
+	return &objectMarshaler{value: v}
+}
+
+// MarshalLogObject adds fields to encoder, use it within implementation of zapcore.ObjectMarshaler
+func MarshalLogObject(enc zapcore.ObjectEncoder, fields ...*Field) error {
+	return marshalFields(enc, fields, 0)
+}
+
+type objectMarshaler struct {
+	value interface{}
+	depth int
+}
+
+func (ref *objectMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return marshalFields(enc, fieldsOf(ref.value), ref.depth)
+}
+
+func fieldsOf(v interface{}) []*Field {
+	if lister, ok := v.(FieldLister); ok {
+		return lister.StrFields()
+	}
+
+	return Fields(v)
+}
+
+func marshalFields(enc zapcore.ObjectEncoder, fields []*Field, depth int) error {
+	for _, f := range fields {
+		if err := marshalField(enc, f, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func marshalField(enc zapcore.ObjectEncoder, f *Field, depth int) error {
+	if f.isRedacted() {
+		enc.AddString(f.fieldName, f.Text())
+		return nil
+	}
+
+	switch f.pattern {
+	case ValuePattern, StringPattern, IntPattern, BooleanPattern, FloatPattern, TimePattern, DurationPattern:
+	default:
+		enc.AddString(f.fieldName, f.Text())
+		return nil
+	}
+
+	v := reflect.ValueOf(f.value)
+	if rv, ok := f.value.(reflect.Value); ok {
+		v = rv
+	}
+
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() && !isObject(v) {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || ((v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()) {
+		enc.AddString(f.fieldName, nilValue)
+		return nil
+	}
+
+	if v.CanInterface() {
+		switch value := v.Interface().(type) {
+		case zapcore.ObjectMarshaler:
+			return enc.AddObject(f.fieldName, value)
+		case FieldLister:
+			return marshalNested(enc, f, value, depth)
+		case time.Time:
+			enc.AddTime(f.fieldName, value)
+			return nil
+		case time.Duration:
+			enc.AddDuration(f.fieldName, value)
+			return nil
+		case fmt.Stringer, error:
+			enc.AddString(f.fieldName, f.Text())
+			return nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		enc.AddBool(f.fieldName, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		enc.AddInt64(f.fieldName, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		enc.AddUint64(f.fieldName, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		enc.AddFloat64(f.fieldName, v.Float())
+	case reflect.String:
+		enc.AddString(f.fieldName, f.Text())
+	case reflect.Struct:
+		if v.CanInterface() {
+			return marshalNested(enc, f, v.Interface(), depth)
+		}
+
+		enc.AddString(f.fieldName, f.Text())
+	default:
+		enc.AddString(f.fieldName, f.Text())
+	}
+
+	return nil
+}
+
+// isObject checks does pointer itself describe value, so it should not be dereferenced
+func isObject(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+
+	switch v.Interface().(type) {
+	case zapcore.ObjectMarshaler, FieldLister, fmt.Stringer, error:
+		return true
+	}
+
+	return false
+}
+
+func marshalNested(enc zapcore.ObjectEncoder, f *Field, value interface{}, depth int) error {
+	if depth+1 >= currentLimits().MaxDepth {
+		enc.AddString(f.fieldName, f.Text())
+		return nil
+	}
+
+	return enc.AddObject(f.fieldName, &objectMarshaler{value: value, depth: depth + 1})
+}
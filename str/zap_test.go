@@ -0,0 +1,100 @@
+package str_test
+
+import (
+	"github.com/proximax-storage/go-xpx-utils/str"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"testing"
+	"time"
+)
+
+type testZapInner struct {
+	Height uint64 `str:"height"`
+}
+
+type testZapTx struct {
+	Hash     string        `str:"hash,s"`
+	Amount   int64         `str:"amount,d"`
+	Fee      float64       `str:"fee"`
+	Signed   bool          `str:"signed"`
+	Deadline time.Duration `str:"deadline"`
+	Key      string        `str:"key,secret"`
+	Inner    testZapInner  `str:"inner"`
+	Tags     []string      `str:"tags"`
+	Id       uint32        `str:"id,x"`
+}
+
+type testZapLister struct {
+	name string
+}
+
+func (ref *testZapLister) StrFields() []*str.Field {
+	return []*str.Field{str.NewField("name", str.StringPattern, ref.name)}
+}
+
+func TestMarshaler_Typed(t *testing.T) {
+	tx := &testZapTx{
+		Hash:     "abcd",
+		Amount:   -5,
+		Fee:      0.5,
+		Signed:   true,
+		Deadline: time.Hour,
+		Key:      "private",
+		Inner:    testZapInner{Height: 10},
+		Tags:     []string{"a", "b"},
+		Id:       255,
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	assert.Nil(t, str.Marshaler(tx).MarshalLogObject(enc))
+
+	assert.Equal(t, map[string]interface{}{
+		"hash":     "abcd",
+		"amount":   int64(-5),
+		"fee":      0.5,
+		"signed":   true,
+		"deadline": time.Hour,
+		"key":      "***",
+		"inner":    map[string]interface{}{"height": uint64(10)},
+		"tags":     "[a b]",
+		"id":       "ff",
+	}, enc.Fields)
+}
+
+func TestMarshaler_FieldLister(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	assert.Nil(t, str.Marshaler(&testZapLister{name: "tx"}).MarshalLogObject(enc))
+	assert.Equal(t, map[string]interface{}{"name": "tx"}, enc.Fields)
+}
+
+func TestFieldList_MarshalLogObject(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	fields := str.FieldList{
+		str.NewField("one", str.StringPattern, "Hello"),
+		str.NewField("two", str.ValuePattern, &testZapLister{name: "nested"}),
+		str.NewField("three", str.ValuePattern, nil),
+		str.RedactedField("password", "abcd"),
+	}
+
+	assert.Nil(t, fields.MarshalLogObject(enc))
+	assert.Equal(t, map[string]interface{}{
+		"one":      "Hello",
+		"two":      map[string]interface{}{"name": "nested"},
+		"three":    "<nil>",
+		"password": "***",
+	}, enc.Fields)
+}
+
+type testZapNode struct {
+	Name string       `str:"name"`
+	Next *testZapNode `str:"next"`
+}
+
+func TestMarshaler_Cycle(t *testing.T) {
+	node := &testZapNode{Name: "a"}
+	node.Next = node
+
+	enc := zapcore.NewMapObjectEncoder()
+	assert.Nil(t, str.Marshaler(node).MarshalLogObject(enc))
+	assert.Equal(t, "a", enc.Fields["name"])
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+)
+
+// Uint64DTO is catapult representation of 64-bit value as [lo, hi] uint32 array
+type Uint64DTO [2]uint32
+
+func NewUint64DTO(v uint64) Uint64DTO {
+	return Uint64DTO{uint32(v), uint32(v >> 32)}
+}
+
+// Uint64DTOFromBigInt returns ErrBigIntOverflow when value does not fit into uint64
+func Uint64DTOFromBigInt(value *big.Int) (Uint64DTO, error) {
+	if value.Sign() < 0 || value.BitLen() > 64 {
+		return Uint64DTO{}, ErrBigIntOverflow
+	}
+
+	return NewUint64DTO(binary.LittleEndian.Uint64(BigIntToByteArray(value, 8))), nil
+}
+
+// Uint64DTOFromHex parses big endian hex string like returned by ToHex
+func Uint64DTOFromHex(s string) (Uint64DTO, error) {
+	b, err := HexDecodeStringOdd(s)
+	if err != nil {
+		return Uint64DTO{}, err
+	}
+
+	return Uint64DTOFromBigInt(BytesToBigIntegerBE(b))
+}
+
+func (ref Uint64DTO) ToUint64() uint64 {
+	return uint64(ref[1])<<32 | uint64(ref[0])
+}
+
+func (ref Uint64DTO) ToBigInt() *big.Int {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, ref.ToUint64())
+
+	return BytesToBigInteger(b)
+}
+
+// ToHex returns 16 characters big endian hex string
+func (ref Uint64DTO) ToHex() string {
+	return hex.EncodeToString(BigIntToByteArrayBE(ref.ToBigInt(), 8))
+}
+
+func (ref Uint64DTO) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]uint32(ref))
+}
+
+// UnmarshalJSON leaves value unchanged for null like encoding/json does
+func (ref *Uint64DTO) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var values []uint32
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	if len(values) != 2 {
+		return ErrInvalidUint64DTO
+	}
+
+	ref[0], ref[1] = values[0], values[1]
+
+	return nil
+}
+
+type Uint64DTOs []Uint64DTO
+
+func NewUint64DTOs(values []uint64) Uint64DTOs {
+	dtos := make(Uint64DTOs, len(values))
+
+	for i, v := range values {
+		dtos[i] = NewUint64DTO(v)
+	}
+
+	return dtos
+}
+
+func (ref Uint64DTOs) ToUint64s() []uint64 {
+	values := make([]uint64, len(ref))
+
+	for i, dto := range ref {
+		values[i] = dto.ToUint64()
+	}
+
+	return values
+}
+
+type Uint64DTOMap map[string]Uint64DTO
+
+func NewUint64DTOMap(values map[string]uint64) Uint64DTOMap {
+	dtos := make(Uint64DTOMap, len(values))
+
+	for k, v := range values {
+		dtos[k] = NewUint64DTO(v)
+	}
+
+	return dtos
+}
+
+func (ref Uint64DTOMap) ToUint64Map() map[string]uint64 {
+	values := make(map[string]uint64, len(ref))
+
+	for k, dto := range ref {
+		values[k] = dto.ToUint64()
+	}
+
+	return values
+}
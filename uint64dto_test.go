@@ -0,0 +1,52 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestUint64DTO(t *testing.T) {
+	dto := NewUint64DTO(0x0000000200000001)
+
+	assert.Equal(t, Uint64DTO{1, 2}, dto)
+	assert.Equal(t, uint64(0x0000000200000001), dto.ToUint64())
+	assert.Equal(t, big.NewInt(0x0000000200000001), dto.ToBigInt())
+	assert.Equal(t, "0000000200000001", dto.ToHex())
+
+	fromHex, err := Uint64DTOFromHex(dto.ToHex())
+	assert.Nil(t, err)
+	assert.Equal(t, dto, fromHex)
+
+	_, err = Uint64DTOFromBigInt(new(big.Int).Lsh(big.NewInt(1), 64))
+	assert.Equal(t, ErrBigIntOverflow, err)
+}
+
+func TestUint64DTO_JSON(t *testing.T) {
+	var dtos Uint64DTOs
+
+	err := json.Unmarshal([]byte(`[[1,2],[4294967295,0]]`), &dtos)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{0x0000000200000001, 0xffffffff}, dtos.ToUint64s())
+
+	b, err := json.Marshal(NewUint64DTOMap(map[string]uint64{"amount": 5}))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"amount":[5,0]}`, string(b))
+
+	dto := Uint64DTO{}
+	assert.Equal(t, ErrInvalidUint64DTO, json.Unmarshal([]byte(`[1]`), &dto))
+}
+
+func TestUint64DTO_JSONNull(t *testing.T) {
+	dto := &struct {
+		Height Uint64DTO `json:"height"`
+	}{Height: NewUint64DTO(7)}
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"height":null}`), dto))
+	assert.Equal(t, uint64(7), dto.Height.ToUint64())
+}
@@ -36,6 +36,7 @@ func HexDecodeStringOdd(s string) ([]byte, error) {
 }
 
 //BigIntToByteArray converts a BigInteger to a little endian byte array.
+// Sign of value is ignored, use SignedBigIntToByteArray for negative values.
 func BigIntToByteArray(value *big.Int, numBytes int) []byte {
 	// output must tohave lenght NumBytes!
 	outputBytes := make([]byte, numBytes)
@@ -30,3 +30,33 @@ func TestBigIntToByteArray(t *testing.T) {
 
 	assert.Equal(t, make([]byte, 32), b)
 }
+
+func TestSignedBigIntToByteArray(t *testing.T) {
+	b, err := SignedBigIntToByteArray(big.NewInt(-2), 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xfe, 0xff, 0xff, 0xff}, b)
+	assert.Equal(t, big.NewInt(-2), BytesToSignedBigInteger(b))
+
+	b, err = SignedBigIntToByteArray(big.NewInt(-128), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x80}, b)
+
+	_, err = SignedBigIntToByteArray(big.NewInt(128), 1)
+	assert.Equal(t, ErrBigIntOverflow, err)
+
+	_, err = SignedBigIntToByteArray(big.NewInt(-129), 1)
+	assert.Equal(t, ErrBigIntOverflow, err)
+
+	assert.Equal(t, big.NewInt(127), BytesToSignedBigInteger([]byte{0x7f}))
+}
+
+func TestBigIntToByteArrayBE(t *testing.T) {
+	b := BigIntToByteArrayBE(big.NewInt(0x0102), 4)
+	assert.Equal(t, []byte{0x00, 0x00, 0x01, 0x02}, b)
+	assert.Equal(t, big.NewInt(0x0102), BytesToBigIntegerBE(b))
+
+	b, err := SignedBigIntToByteArrayBE(big.NewInt(-2), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xff, 0xfe}, b)
+	assert.Equal(t, big.NewInt(-2), BytesToSignedBigIntegerBE(b))
+}
@@ -0,0 +1,258 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"io"
+	"math/big"
+)
+
+// MaxVarintLen64 is the maximum length of LEB128 encoded 64-bit value
+const MaxVarintLen64 = 10
+
+// MaxBigVarintLen is the maximum length of LEB128 encoded big integer, it is enough for 7168 bits values.
+// Encoding bigger values and decoding longer input fail with ErrVarintOverflow
+const MaxBigVarintLen = 1024
+
+// EncodeUvarint encodes v as unsigned LEB128
+func EncodeUvarint(v uint64) []byte {
+	buf := make([]byte, 0, MaxVarintLen64)
+
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(buf, byte(v))
+}
+
+// EncodeVarint encodes v as zigzag signed LEB128
+func EncodeVarint(v int64) []byte {
+	return EncodeUvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+// DecodeUvarint decodes unsigned LEB128 from the beginning of b and returns count of read bytes.
+// Truncated, overlong and overflowing inputs are rejected
+func DecodeUvarint(b []byte) (uint64, int, error) {
+	return decodeUvarint(byteSliceReader(b))
+}
+
+// DecodeVarint decodes zigzag signed LEB128 from the beginning of b and returns count of read bytes
+func DecodeVarint(b []byte) (int64, int, error) {
+	u, n, err := DecodeUvarint(b)
+	if err != nil {
+		return 0, n, err
+	}
+
+	return unzigzag(u), n, nil
+}
+
+// ReadUvarint reads unsigned LEB128 from r
+func ReadUvarint(r io.ByteReader) (uint64, error) {
+	v, _, err := decodeUvarint(r.ReadByte)
+
+	return v, err
+}
+
+// ReadVarint reads zigzag signed LEB128 from r
+func ReadVarint(r io.ByteReader) (int64, error) {
+	u, _, err := decodeUvarint(r.ReadByte)
+	if err != nil {
+		return 0, err
+	}
+
+	return unzigzag(u), nil
+}
+
+// WriteUvarint writes unsigned LEB128 to w
+func WriteUvarint(w io.Writer, v uint64) (int, error) {
+	return w.Write(EncodeUvarint(v))
+}
+
+// WriteVarint writes zigzag signed LEB128 to w
+func WriteVarint(w io.Writer, v int64) (int, error) {
+	return w.Write(EncodeVarint(v))
+}
+
+// EncodeBigUvarint encodes non negative value as unsigned LEB128
+func EncodeBigUvarint(value *big.Int) ([]byte, error) {
+	if value.Sign() < 0 {
+		return nil, ErrVarintNegative
+	}
+
+	if value.BitLen() > 7*MaxBigVarintLen {
+		return nil, ErrVarintOverflow
+	}
+
+	v := new(big.Int).Set(value)
+	mask := big.NewInt(0x7f)
+	digit := new(big.Int)
+
+	buf := make([]byte, 0, v.BitLen()/7+1)
+
+	for v.BitLen() > 7 {
+		buf = append(buf, byte(digit.And(v, mask).Uint64())|0x80)
+		v.Rsh(v, 7)
+	}
+
+	return append(buf, byte(v.Uint64())), nil
+}
+
+// EncodeBigVarint encodes value as zigzag signed LEB128
+func EncodeBigVarint(value *big.Int) ([]byte, error) {
+	return EncodeBigUvarint(zigzagBig(value))
+}
+
+// DecodeBigUvarint decodes unsigned LEB128 of any size from the beginning of b and returns count of read bytes
+func DecodeBigUvarint(b []byte) (*big.Int, int, error) {
+	return decodeBigUvarint(byteSliceReader(b))
+}
+
+// DecodeBigVarint decodes zigzag signed LEB128 of any size from the beginning of b and returns count of read bytes
+func DecodeBigVarint(b []byte) (*big.Int, int, error) {
+	u, n, err := DecodeBigUvarint(b)
+	if err != nil {
+		return nil, n, err
+	}
+
+	return unzigzagBig(u), n, nil
+}
+
+// ReadBigUvarint reads unsigned LEB128 of any size from r
+func ReadBigUvarint(r io.ByteReader) (*big.Int, error) {
+	v, _, err := decodeBigUvarint(r.ReadByte)
+
+	return v, err
+}
+
+// ReadBigVarint reads zigzag signed LEB128 of any size from r
+func ReadBigVarint(r io.ByteReader) (*big.Int, error) {
+	u, _, err := decodeBigUvarint(r.ReadByte)
+	if err != nil {
+		return nil, err
+	}
+
+	return unzigzagBig(u), nil
+}
+
+// WriteBigUvarint writes non negative value as unsigned LEB128 to w
+func WriteBigUvarint(w io.Writer, value *big.Int) (int, error) {
+	b, err := EncodeBigUvarint(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return w.Write(b)
+}
+
+// WriteBigVarint writes value as zigzag signed LEB128 to w
+func WriteBigVarint(w io.Writer, value *big.Int) (int, error) {
+	b, err := EncodeBigVarint(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return w.Write(b)
+}
+
+func byteSliceReader(b []byte) func() (byte, error) {
+	idx := 0
+
+	return func() (byte, error) {
+		if idx >= len(b) {
+			return 0, io.EOF
+		}
+
+		idx++
+
+		return b[idx-1], nil
+	}
+}
+
+func decodeUvarint(next func() (byte, error)) (uint64, int, error) {
+	var v uint64
+
+	for i := 0; i < MaxVarintLen64; i++ {
+		b, err := next()
+		if err != nil {
+			return 0, i, varintReadError(err, i)
+		}
+
+		if i == MaxVarintLen64-1 && b > 1 {
+			return 0, i + 1, ErrVarintOverflow
+		}
+
+		v |= uint64(b&0x7f) << (7 * uint(i))
+
+		if b < 0x80 {
+			if b == 0 && i > 0 {
+				return 0, i + 1, ErrVarintNotMinimal
+			}
+
+			return v, i + 1, nil
+		}
+	}
+
+	return 0, MaxVarintLen64, ErrVarintOverflow
+}
+
+func decodeBigUvarint(next func() (byte, error)) (*big.Int, int, error) {
+	v := new(big.Int)
+	digit := new(big.Int)
+
+	for i := 0; i < MaxBigVarintLen; i++ {
+		b, err := next()
+		if err != nil {
+			return nil, i, varintReadError(err, i)
+		}
+
+		digit.SetUint64(uint64(b & 0x7f))
+		v.Or(v, digit.Lsh(digit, 7*uint(i)))
+
+		if b < 0x80 {
+			if b == 0 && i > 0 {
+				return nil, i + 1, ErrVarintNotMinimal
+			}
+
+			return v, i + 1, nil
+		}
+	}
+
+	return nil, MaxBigVarintLen, ErrVarintOverflow
+}
+
+func varintReadError(err error, read int) error {
+	if err == io.EOF && read > 0 {
+		return ErrVarintTruncated
+	}
+
+	return err
+}
+
+func unzigzag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func zigzagBig(v *big.Int) *big.Int {
+	z := new(big.Int).Lsh(v, 1)
+
+	if v.Sign() < 0 {
+		z.Neg(z)
+		z.Sub(z, big.NewInt(1))
+	}
+
+	return z
+}
+
+func unzigzagBig(u *big.Int) *big.Int {
+	v := new(big.Int).Rsh(u, 1)
+
+	if u.Bit(0) == 1 {
+		v.Neg(v)
+		v.Sub(v, big.NewInt(1))
+	}
+
+	return v
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestUvarint(t *testing.T) {
+	assert.Equal(t, []byte{0xe5, 0x8e, 0x26}, EncodeUvarint(624485))
+
+	v, n, err := DecodeUvarint([]byte{0xe5, 0x8e, 0x26, 0xff})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(624485), v)
+	assert.Equal(t, 3, n)
+
+	max := EncodeUvarint(math.MaxUint64)
+	assert.Len(t, max, MaxVarintLen64)
+	v, _, err = DecodeUvarint(max)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), v)
+
+	_, _, err = DecodeUvarint([]byte{0x80, 0x80})
+	assert.Equal(t, ErrVarintTruncated, err)
+
+	_, _, err = DecodeUvarint([]byte{0x81, 0x00})
+	assert.Equal(t, ErrVarintNotMinimal, err)
+
+	_, _, err = DecodeUvarint([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02})
+	assert.Equal(t, ErrVarintOverflow, err)
+
+	_, _, err = DecodeUvarint(nil)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestVarint(t *testing.T) {
+	for _, v := range []int64{0, -1, 1, -64, 64, math.MinInt64, math.MaxInt64} {
+		got, _, err := DecodeVarint(EncodeVarint(v))
+		assert.Nil(t, err)
+		assert.Equal(t, v, got)
+	}
+
+	assert.Equal(t, []byte{0x03}, EncodeVarint(-2))
+}
+
+func TestBigVarint(t *testing.T) {
+	value, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	buf := &bytes.Buffer{}
+	_, err := WriteBigUvarint(buf, value)
+	assert.Nil(t, err)
+
+	got, err := ReadBigUvarint(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, value, got)
+
+	b, err := EncodeBigUvarint(big.NewInt(624485))
+	assert.Nil(t, err)
+	assert.Equal(t, EncodeUvarint(624485), b)
+
+	neg := new(big.Int).Neg(value)
+	b, err = EncodeBigVarint(neg)
+	assert.Nil(t, err)
+	got, _, err = DecodeBigVarint(b)
+	assert.Nil(t, err)
+	assert.Equal(t, neg, got)
+	b, err = EncodeBigVarint(big.NewInt(-2))
+	assert.Nil(t, err)
+	assert.Equal(t, EncodeVarint(-2), b)
+
+	_, err = EncodeBigUvarint(big.NewInt(-1))
+	assert.Equal(t, ErrVarintNegative, err)
+}
+
+func TestBigVarint_MaxLen(t *testing.T) {
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 7*MaxBigVarintLen), big.NewInt(1))
+
+	b, err := EncodeBigUvarint(max)
+	assert.Nil(t, err)
+	assert.Len(t, b, MaxBigVarintLen)
+
+	got, n, err := DecodeBigUvarint(b)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxBigVarintLen, n)
+	assert.Equal(t, 0, max.Cmp(got))
+
+	_, err = EncodeBigUvarint(new(big.Int).Add(max, big.NewInt(1)))
+	assert.Equal(t, ErrVarintOverflow, err)
+
+	_, err = WriteBigVarint(&bytes.Buffer{}, new(big.Int).Neg(max))
+	assert.Equal(t, ErrVarintOverflow, err)
+
+	overlong := bytes.Repeat([]byte{0x80}, MaxBigVarintLen+1)
+	_, n, err = DecodeBigUvarint(overlong)
+	assert.Equal(t, ErrVarintOverflow, err)
+	assert.Equal(t, MaxBigVarintLen, n)
+
+	_, err = ReadBigVarint(bytes.NewReader(overlong))
+	assert.Equal(t, ErrVarintOverflow, err)
+}